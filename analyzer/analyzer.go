@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
@@ -11,32 +12,117 @@ import (
 	"telegram_message_analyzer/stopwords"
 )
 
-// YearStats contains statistics for a single year
+// YearStats contains statistics for a single year or report period
 type YearStats struct {
-	Year                int
-	TotalMessages       int
-	MessagesByUser      map[string]int
-	WordFrequency       map[string]int
-	WordFrequencyByUser map[string]map[string]int // user -> word -> count
-	TopWords            []WordCount
-	TopWordsByUser      map[string][]WordCount // user -> top words
-	HourlyActivity      map[int]int            // hour -> count
-	MonthlyActivity     map[string]int         // "YYYY-MM" -> count
-	MostActiveWindow    TimeWindow
-	MostActiveMonth     MonthStat
-	FirstMessage        time.Time
-	LastMessage         time.Time
-	RepliesCount        int
-	ForwardedCount      int
-	AvgMessageLength    float64
+	Year                  int
+	Period                Period // report period; calendar year for ByYear entries
+	TotalMessages         int
+	MessagesByUser        map[string]int
+	WordFrequency         map[string]int
+	WordFrequencyByUser   map[string]map[string]int // user -> word -> count
+	WordForms             map[string]map[string]int // normalised word -> surface form -> count
+	EmojiFrequency        map[string]int            // emoji -> count
+	EmojiFrequencyByUser  map[string]map[string]int // user -> emoji -> count
+	EmojiCount            int
+	EmojiCountByUser      map[string]int
+	TopEmoji              []WordCount
+	TopEmojiByUser        map[string][]WordCount // user -> top emoji
+	Sentiment             SentimentStat
+	SentimentByMonth      map[string]*SentimentStat            // "YYYY-MM" -> sentiment
+	SentimentByDay        map[string]*SentimentStat            // "YYYY-MM-DD" -> sentiment
+	SentimentByUser       map[string]*SentimentStat            // user -> sentiment
+	SentimentByUserMonth  map[string]map[string]*SentimentStat // user -> "YYYY-MM" -> sentiment
+	MostPositiveDays      []DaySentiment
+	MostNegativeDays      []DaySentiment
+	MessagesByLanguage    map[string]int            // language code -> count
+	LanguagesByUser       map[string]map[string]int // user -> language code -> count
+	TopWords              []WordCount
+	TopWordsByUser        map[string][]WordCount    // user -> top words
+	PhraseFrequency       map[string]int            // "word word [word]" -> count
+	PhraseFrequencyByUser map[string]map[string]int // user -> phrase -> count
+	TopBigrams            []PhraseCount
+	TopTrigrams           []PhraseCount
+	TopPhrasesByUser      map[string][]PhraseCount // user -> top 2- and 3-word phrases
+	SignatureWords        []WordScore              // words characteristic of this period vs other periods
+	SignatureWordsByUser  map[string][]WordScore   // user -> words characteristic of the user
+	HourlyActivity        map[int]int              // hour -> count
+	MonthlyActivity       map[string]int           // "YYYY-MM" -> count
+	MostActiveWindow      TimeWindow
+	MostActiveMonth       MonthStat
+	FirstMessage          time.Time
+	LastMessage           time.Time
+	RepliesCount          int
+	ForwardedCount        int
+	AvgMessageLength      float64
+	MessageLengths        []int                  // length of every message in characters
+	MessageLengthsByUser  map[string][]int       // user -> message lengths
+	WordCountByUser       map[string]int         // user -> words written
+	LongestMessageByUser  map[string]LongMessage // user -> longest message
+	Lengths               LengthStats
+	LengthsByUser         map[string]LengthStats
+	LengthHistogram       []LengthBucket
+	Vocabulary            VocabularyStats
+	VocabularyByUser      map[string]VocabularyStats
+	NewWords              []NewWord                 // words first used in the chat during this period
+	Runs                  int                       // series of consecutive messages by one person
+	RunMessages           int                       // messages in the runs started in this period
+	RunsByUser            map[string]int            // user -> runs
+	RunMessagesByUser     map[string]int            // user -> messages in the runs started in this period
+	FollowUpsByUser       map[string]int            // user -> runs with a follow-up sent before anyone replied
+	LongestRun            MessageRun                // longest monologue
+	LongestRunByUser      map[string]MessageRun     // user -> longest monologue
+	QuestionsByUser       map[string]int            // user -> questions asked
+	AnsweredByUser        map[string]int            // user -> questions that got an answer
+	AnswerTimeByUser      map[string]time.Duration  // user -> total time waited for answers
+	LaughsByUser          map[string]int            // user -> messages with laughter
+	LaughTriggersByUser   map[string]int            // user -> laughs others replied to the user's messages with
+	LaughedAtByUser       map[string]map[string]int // laughing user -> author of the message laughed at -> count
+	ProfanityFrequency    map[string]int            // obscene word -> count
+	ProfanityByUser       map[string]map[string]int // user -> obscene word -> count
+	ProfanityCountByUser  map[string]int            // user -> obscene words
+	TopProfanity          []WordCount
+	TopProfanityByUser    map[string][]WordCount  // user -> most used obscene words
+	StyleByUser           map[string]*StyleStats  // user -> writing habits
+	TyposByUser           map[string][]Typo       // user -> likely misspellings
+	Conversations         int                     // conversations started after a long silence
+	InitiationsByUser     map[string]*Initiations // user -> conversations started
+	tokens                []string                // all words in message order, dropped after finalize
+	tokensByUser          map[string][]string     // user -> words in message order
 }
 
 // WordCount represents a word with its count
 type WordCount struct {
 	Word  string
+	Form  string // most common surface form when words are normalised
 	Count int
 }
 
+// Options controls how messages are analysed
+type Options struct {
+	Normalization Normalization
+	StopWords     []string // extra stop words and per-chat ignore lists
+	KeepWords     []string // built-in stop words that should still be counted
+	Topics        int      // number of topics to extract (0 disables topic modelling)
+	Track         []TrackedTerm
+	Period        PeriodKind // length of report periods
+	YearStart     time.Month // first month of years and quarters (September for academic years)
+	// ExcludeDuplicates leaves repeated and near-duplicate messages out of word counts
+	ExcludeDuplicates bool
+	// InitiatorSilence is the pause after which the next message starts a new conversation
+	InitiatorSilence time.Duration
+}
+
+// DefaultOptions returns options matching the classic behaviour
+func DefaultOptions() Options {
+	return Options{
+		Normalization:    NormalizeNone,
+		Topics:           8,
+		Period:           PeriodYear,
+		YearStart:        time.January,
+		InitiatorSilence: DefaultInitiatorSilence,
+	}
+}
+
 // TimeWindow represents a 2-hour time window
 type TimeWindow struct {
 	StartHour int
@@ -53,135 +139,340 @@ type MonthStat struct {
 
 // Stats contains all analysis results
 type Stats struct {
-	Overall  YearStats
-	ByYear   map[int]*YearStats
-	ChatName string
-	ChatType string
+	Overall       YearStats
+	ByYear        map[int]*YearStats
+	ByPeriod      map[string]*YearStats // report periods by key, see GetSortedPeriods
+	PeriodKind    PeriodKind
+	YearStart     time.Month // first month of years and quarters
+	ChatName      string
+	ChatType      string
+	StopWords     StopWordInfo
+	Topics        []Topic
+	TopicsByMonth map[string][]float64 // "YYYY-MM" -> share of each topic
+	Terms         []TermStats
+	Bursts        []Burst          // unusually busy days, strongest first
+	Duplicates    []DuplicateGroup // repeated texts, most repeated first
+	// DuplicatesExcluded is set when repeated messages were left out of word counts
+	DuplicatesExcluded bool
+	// InitiatorSilence is the pause after which a message starts a new conversation
+	InitiatorSilence time.Duration
+}
+
+// StopWordInfo describes the effective stop word configuration of a report
+type StopWordInfo struct {
+	Languages []string // languages with built-in lists
+	Added     []string // user-supplied words excluded from counts
+	Kept      []string // built-in stop words that were counted anyway
 }
 
 // Analyze performs full analysis on parsed messages
-func Analyze(result *parser.ParseResult) *Stats {
+func Analyze(result *parser.ParseResult, opts Options) *Stats {
+	if opts.Period == "" {
+		opts.Period = PeriodYear
+	}
+	if opts.YearStart == 0 {
+		opts.YearStart = time.January
+	}
+	if opts.InitiatorSilence <= 0 {
+		opts.InitiatorSilence = DefaultInitiatorSilence
+	}
+
 	stats := &Stats{
-		ByYear:   make(map[int]*YearStats),
-		ChatName: result.Metadata.Name,
-		ChatType: result.Metadata.Type,
-		Overall: YearStats{
-			MessagesByUser:      make(map[string]int),
-			WordFrequency:       make(map[string]int),
-			WordFrequencyByUser: make(map[string]map[string]int),
-			HourlyActivity:      make(map[int]int),
-			MonthlyActivity:     make(map[string]int),
-		},
+		ByYear:           make(map[int]*YearStats),
+		ByPeriod:         make(map[string]*YearStats),
+		PeriodKind:       opts.Period,
+		YearStart:        opts.YearStart,
+		ChatName:         result.Metadata.Name,
+		ChatType:         result.Metadata.Type,
+		Overall:          *newYearStats(0),
+		InitiatorSilence: opts.InitiatorSilence,
+	}
+
+	filter := newWordFilter(opts)
+	stats.StopWords = StopWordInfo{
+		Languages: stopwords.Languages(),
+		Added:     sortedKeys(filter.listed),
+		Kept:      sortedKeys(filter.keep),
 	}
 
+	corpus := newTopicCorpus()
+	firstUses := make(map[string]firstUse)
+	activity := make(map[string]map[string]int) // "YYYY-MM-DD" -> user -> messages
+	runs := &runTracker{}
+	questions := newQuestionTracker()
+	laughs := newLaughTracker()
+	initiators := &initiatorTracker{silence: opts.InitiatorSilence}
+	stats.Terms = newTermStats(opts.Track)
+
+	duplicates, repeat := findDuplicates(result.Messages)
+	stats.Duplicates = duplicates
+	stats.DuplicatesExcluded = opts.ExcludeDuplicates
+
 	// Process each message
-	for _, msg := range result.Messages {
+	for i, msg := range result.Messages {
 		year := msg.Date.Year()
 
 		// Initialize year stats if needed
 		if _, ok := stats.ByYear[year]; !ok {
-			stats.ByYear[year] = &YearStats{
-				Year:                year,
-				MessagesByUser:      make(map[string]int),
-				WordFrequency:       make(map[string]int),
-				WordFrequencyByUser: make(map[string]map[string]int),
-				HourlyActivity:      make(map[int]int),
-				MonthlyActivity:     make(map[string]int),
-			}
+			stats.ByYear[year] = newYearStats(year)
+			stats.ByYear[year].Period = PeriodOf(msg.Date, PeriodYear, time.January)
 		}
-
 		yearStats := stats.ByYear[year]
 
-		// Count messages
-		yearStats.TotalMessages++
-		stats.Overall.TotalMessages++
-
-		// Count by user
-		yearStats.MessagesByUser[msg.From]++
-		stats.Overall.MessagesByUser[msg.From]++
-
-		// Count replies and forwards
-		if msg.IsReply {
-			yearStats.RepliesCount++
-			stats.Overall.RepliesCount++
-		}
-		if msg.IsForwarded {
-			yearStats.ForwardedCount++
-			stats.Overall.ForwardedCount++
+		// Every message is counted in its year, its report period and the overall stats
+		targets := []*YearStats{yearStats, &stats.Overall}
+		period := PeriodOf(msg.Date, stats.PeriodKind, stats.YearStart)
+		if stats.calendarYears() {
+			stats.ByPeriod[period.Key] = yearStats
+		} else {
+			if _, ok := stats.ByPeriod[period.Key]; !ok {
+				stats.ByPeriod[period.Key] = newYearStats(period.Start.Year())
+				stats.ByPeriod[period.Key].Period = period
+			}
+			targets = append(targets, stats.ByPeriod[period.Key])
 		}
 
-		// Track message length
-		yearStats.AvgMessageLength += float64(msg.Length)
-		stats.Overall.AvgMessageLength += float64(msg.Length)
+		monthKey := msg.Date.Format("2006-01")
+
+		// Tracked terms
+		countTrackedTerms(opts.Track, stats.Terms, msg.From, monthKey, msg.Text)
 
-		// Track first/last messages
-		if yearStats.FirstMessage.IsZero() || msg.Date.Before(yearStats.FirstMessage) {
-			yearStats.FirstMessage = msg.Date
+		// Words left after stop word filtering
+		lang := DetectLanguage(msg.Text)
+		info := &messageInfo{
+			emoji:     extractEmoji(msg.Text),
+			profanity: findProfanity(msg.Text),
+			sentiment: ScoreSentiment(msg.Text),
+			lang:      lang,
 		}
-		if yearStats.LastMessage.IsZero() || msg.Date.After(yearStats.LastMessage) {
-			yearStats.LastMessage = msg.Date
+		info.tokens = extractWords(msg.Text)
+		countWords := !opts.ExcludeDuplicates || !repeat[i]
+		for _, form := range info.tokens {
+			word := normalizeWord(form, opts.Normalization)
+			if countWords && !filter.isStopWord(lang, form, word) && len([]rune(form)) > 1 {
+				info.forms = append(info.forms, form)
+				info.words = append(info.words, word)
+			}
 		}
 
-		// Hourly activity
-		hour := msg.Date.Hour()
-		yearStats.HourlyActivity[hour]++
-		stats.Overall.HourlyActivity[hour]++
-
-		// Monthly activity
-		monthKey := msg.Date.Format("2006-01")
-		yearStats.MonthlyActivity[monthKey]++
-		stats.Overall.MonthlyActivity[monthKey]++
-
-		// Word frequency (overall and by user)
-		words := extractWords(msg.Text)
-		for _, word := range words {
-			if !stopwords.IsStopWord(word) && len([]rune(word)) > 1 {
-				yearStats.WordFrequency[word]++
-				stats.Overall.WordFrequency[word]++
-
-				// By user - year stats
-				if yearStats.WordFrequencyByUser[msg.From] == nil {
-					yearStats.WordFrequencyByUser[msg.From] = make(map[string]int)
-				}
-				yearStats.WordFrequencyByUser[msg.From][word]++
-
-				// By user - overall stats
-				if stats.Overall.WordFrequencyByUser[msg.From] == nil {
-					stats.Overall.WordFrequencyByUser[msg.From] = make(map[string]int)
-				}
-				stats.Overall.WordFrequencyByUser[msg.From][word]++
+		day := msg.Date.Format("2006-01-02")
+		corpus.add(day, info.words)
+		incNested(activity, day, msg.From)
+		for _, word := range info.words {
+			if _, ok := firstUses[word]; !ok {
+				firstUses[word] = firstUse{user: msg.From, date: msg.Date}
 			}
 		}
+
+		for _, ys := range targets {
+			ys.addMessage(msg, info, opts.Normalization != NormalizeNone)
+		}
+		runs.add(msg, targets)
+		questions.add(msg, targets)
+		laughs.add(msg, targets)
+		initiators.add(msg, targets)
 	}
+	runs.flush()
 
 	// Calculate averages and top stats
 	for _, yearStats := range stats.ByYear {
-		if yearStats.TotalMessages > 0 {
-			yearStats.AvgMessageLength /= float64(yearStats.TotalMessages)
+		yearStats.finalize()
+	}
+	if !stats.calendarYears() {
+		for _, periodStats := range stats.ByPeriod {
+			periodStats.finalize()
 		}
-		yearStats.TopWords = getTopWords(yearStats.WordFrequency, 20)
-		yearStats.TopWordsByUser = make(map[string][]WordCount)
-		for user, wordFreq := range yearStats.WordFrequencyByUser {
-			yearStats.TopWordsByUser[user] = getTopWords(wordFreq, 20)
+	}
+	stats.Overall.finalize()
+	computeSignatures(stats)
+	computeNewWords(stats, firstUses)
+	computeBursts(stats, activity, corpus)
+	computeTopics(stats, corpus, opts.Topics)
+	stats.Overall.FirstMessage = result.Metadata.FirstMessage
+	stats.Overall.LastMessage = result.Metadata.LastMessage
+
+	return stats
+}
+
+// messageInfo holds what is extracted from a message once and counted in every aggregate
+type messageInfo struct {
+	emoji     []string
+	profanity []string // obscene words in their deobfuscated form
+	sentiment float64
+	lang      string
+	tokens    []string // all words of the message, including stop words
+	forms     []string // surface forms of the counted words
+	words     []string // counted words after normalisation
+}
+
+// addMessage counts a message in the statistics of a period
+func (ys *YearStats) addMessage(msg parser.Message, info *messageInfo, trackForms bool) {
+	// Count messages
+	ys.TotalMessages++
+
+	// Count by user
+	ys.MessagesByUser[msg.From]++
+
+	// Count replies and forwards
+	if msg.IsReply {
+		ys.RepliesCount++
+	}
+	if msg.IsForwarded {
+		ys.ForwardedCount++
+	}
+
+	// Track message length
+	ys.AvgMessageLength += float64(msg.Length)
+	ys.addLength(msg)
+
+	// Track first/last messages
+	if ys.FirstMessage.IsZero() || msg.Date.Before(ys.FirstMessage) {
+		ys.FirstMessage = msg.Date
+	}
+	if ys.LastMessage.IsZero() || msg.Date.After(ys.LastMessage) {
+		ys.LastMessage = msg.Date
+	}
+
+	// Hourly and monthly activity
+	ys.HourlyActivity[msg.Date.Hour()]++
+	ys.MonthlyActivity[msg.Date.Format("2006-01")]++
+
+	// Emoji
+	for _, emoji := range info.emoji {
+		ys.EmojiFrequency[emoji]++
+		incNested(ys.EmojiFrequencyByUser, msg.From, emoji)
+		ys.EmojiCount++
+		ys.EmojiCountByUser[msg.From]++
+	}
+
+	// Profanity
+	for _, word := range info.profanity {
+		ys.ProfanityFrequency[word]++
+		incNested(ys.ProfanityByUser, msg.From, word)
+		ys.ProfanityCountByUser[msg.From]++
+	}
+
+	// Sentiment
+	ys.addSentiment(msg.From, msg.Date, info.sentiment)
+
+	// Writing style
+	ys.addStyle(msg.From, msg.Text)
+
+	// Language mix
+	ys.MessagesByLanguage[info.lang]++
+	incNested(ys.LanguagesByUser, msg.From, info.lang)
+
+	// Vocabulary richness
+	ys.tokens = append(ys.tokens, info.tokens...)
+	ys.tokensByUser[msg.From] = append(ys.tokensByUser[msg.From], info.tokens...)
+
+	// Word frequency (overall and by user)
+	for i, word := range info.words {
+		if trackForms {
+			incNested(ys.WordForms, word, info.forms[i])
 		}
-		yearStats.MostActiveWindow = getMostActiveWindow(yearStats.HourlyActivity)
-		yearStats.MostActiveMonth = getMostActiveMonth(yearStats.MonthlyActivity)
+		ys.WordFrequency[word]++
+		incNested(ys.WordFrequencyByUser, msg.From, word)
 	}
 
-	if stats.Overall.TotalMessages > 0 {
-		stats.Overall.AvgMessageLength /= float64(stats.Overall.TotalMessages)
+	// Phrases of adjacent words left after stop word filtering
+	addPhrases(ys.PhraseFrequency, info.words, 2, 3)
+	if ys.PhraseFrequencyByUser[msg.From] == nil {
+		ys.PhraseFrequencyByUser[msg.From] = make(map[string]int)
 	}
-	stats.Overall.TopWords = getTopWords(stats.Overall.WordFrequency, 20)
-	stats.Overall.TopWordsByUser = make(map[string][]WordCount)
-	for user, wordFreq := range stats.Overall.WordFrequencyByUser {
-		stats.Overall.TopWordsByUser[user] = getTopWords(wordFreq, 20)
+	addPhrases(ys.PhraseFrequencyByUser[msg.From], info.words, 2, 3)
+}
+
+// newYearStats creates empty statistics for a year (0 for overall stats)
+func newYearStats(year int) *YearStats {
+	return &YearStats{
+		Year:                  year,
+		MessagesByUser:        make(map[string]int),
+		WordFrequency:         make(map[string]int),
+		WordFrequencyByUser:   make(map[string]map[string]int),
+		WordForms:             make(map[string]map[string]int),
+		PhraseFrequency:       make(map[string]int),
+		PhraseFrequencyByUser: make(map[string]map[string]int),
+		EmojiFrequency:        make(map[string]int),
+		EmojiFrequencyByUser:  make(map[string]map[string]int),
+		EmojiCountByUser:      make(map[string]int),
+		SentimentByMonth:      make(map[string]*SentimentStat),
+		SentimentByDay:        make(map[string]*SentimentStat),
+		SentimentByUser:       make(map[string]*SentimentStat),
+		SentimentByUserMonth:  make(map[string]map[string]*SentimentStat),
+		MessagesByLanguage:    make(map[string]int),
+		LanguagesByUser:       make(map[string]map[string]int),
+		HourlyActivity:        make(map[int]int),
+		MonthlyActivity:       make(map[string]int),
+		MessageLengthsByUser:  make(map[string][]int),
+		WordCountByUser:       make(map[string]int),
+		LongestMessageByUser:  make(map[string]LongMessage),
+		RunsByUser:            make(map[string]int),
+		RunMessagesByUser:     make(map[string]int),
+		FollowUpsByUser:       make(map[string]int),
+		LongestRunByUser:      make(map[string]MessageRun),
+		QuestionsByUser:       make(map[string]int),
+		AnsweredByUser:        make(map[string]int),
+		AnswerTimeByUser:      make(map[string]time.Duration),
+		LaughsByUser:          make(map[string]int),
+		LaughTriggersByUser:   make(map[string]int),
+		LaughedAtByUser:       make(map[string]map[string]int),
+		ProfanityFrequency:    make(map[string]int),
+		ProfanityByUser:       make(map[string]map[string]int),
+		ProfanityCountByUser:  make(map[string]int),
+		StyleByUser:           make(map[string]*StyleStats),
+		InitiationsByUser:     make(map[string]*Initiations),
+		tokensByUser:          make(map[string][]string),
 	}
-	stats.Overall.MostActiveWindow = getMostActiveWindow(stats.Overall.HourlyActivity)
-	stats.Overall.MostActiveMonth = getMostActiveMonth(stats.Overall.MonthlyActivity)
-	stats.Overall.FirstMessage = result.Metadata.FirstMessage
-	stats.Overall.LastMessage = result.Metadata.LastMessage
+}
 
-	return stats
+// finalize calculates averages and top lists once all messages are counted
+func (ys *YearStats) finalize() {
+	if ys.TotalMessages > 0 {
+		ys.AvgMessageLength /= float64(ys.TotalMessages)
+	}
+	ys.Lengths = getLengthStats(ys.MessageLengths)
+	ys.LengthHistogram = getLengthHistogram(ys.MessageLengths)
+	ys.LengthsByUser = make(map[string]LengthStats)
+	for user, lengths := range ys.MessageLengthsByUser {
+		ys.LengthsByUser[user] = getLengthStats(lengths)
+	}
+	ys.finalizeVocabulary()
+	ys.computeTypos()
+	ys.TopWords = getTopWords(ys.WordFrequency, 20)
+	setWordForms(ys.TopWords, ys.WordForms)
+	ys.TopWordsByUser = make(map[string][]WordCount)
+	for user, wordFreq := range ys.WordFrequencyByUser {
+		ys.TopWordsByUser[user] = getTopWords(wordFreq, 20)
+		setWordForms(ys.TopWordsByUser[user], ys.WordForms)
+	}
+
+	ys.TopBigrams = getTopPhrases(ys.PhraseFrequency, 2, 20)
+	setPhraseForms(ys.TopBigrams, ys.WordForms)
+	ys.TopTrigrams = getTopPhrases(ys.PhraseFrequency, 3, 20)
+	setPhraseForms(ys.TopTrigrams, ys.WordForms)
+	ys.TopPhrasesByUser = make(map[string][]PhraseCount)
+	for user, phraseFreq := range ys.PhraseFrequencyByUser {
+		ys.TopPhrasesByUser[user] = getTopPhrasesMixed(phraseFreq, 10)
+		setPhraseForms(ys.TopPhrasesByUser[user], ys.WordForms)
+	}
+
+	ys.TopEmoji = getTopWords(ys.EmojiFrequency, 20)
+	ys.TopEmojiByUser = make(map[string][]WordCount)
+	for user, emojiFreq := range ys.EmojiFrequencyByUser {
+		ys.TopEmojiByUser[user] = getTopWords(emojiFreq, 10)
+	}
+	ys.TopProfanity = getTopWords(ys.ProfanityFrequency, 10)
+	ys.TopProfanityByUser = make(map[string][]WordCount)
+	for user, freq := range ys.ProfanityByUser {
+		ys.TopProfanityByUser[user] = getTopWords(freq, 3)
+	}
+
+	ys.MostPositiveDays = getMoodiestDays(ys.SentimentByDay, 5, true)
+	ys.MostNegativeDays = getMoodiestDays(ys.SentimentByDay, 5, false)
+
+	ys.MostActiveWindow = getMostActiveWindow(ys.HourlyActivity)
+	ys.MostActiveMonth = getMostActiveMonth(ys.MonthlyActivity)
 }
 
 // extractWords extracts and normalizes words from text
@@ -229,7 +520,7 @@ func isNumber(s string) bool {
 	return true
 }
 
-// getTopWords returns top N words by frequency
+// getTopWords returns top N words by frequency, alphabetically on ties
 func getTopWords(freq map[string]int, n int) []WordCount {
 	words := make([]WordCount, 0, len(freq))
 	for word, count := range freq {
@@ -237,7 +528,10 @@ func getTopWords(freq map[string]int, n int) []WordCount {
 	}
 
 	sort.Slice(words, func(i, j int) bool {
-		return words[i].Count > words[j].Count
+		if words[i].Count != words[j].Count {
+			return words[i].Count > words[j].Count
+		}
+		return words[i].Word < words[j].Word
 	})
 
 	if len(words) > n {
@@ -247,6 +541,40 @@ func getTopWords(freq map[string]int, n int) []WordCount {
 	return words
 }
 
+// incNested increments a counter in a two-level map, creating the inner map if needed
+func incNested(m map[string]map[string]int, key, sub string) {
+	if m[key] == nil {
+		m[key] = make(map[string]int)
+	}
+	m[key][sub]++
+}
+
+// setWordForms fills Form with the most common surface form of each word
+func setWordForms(words []WordCount, forms map[string]map[string]int) {
+	for i := range words {
+		words[i].Form = mostCommonKey(forms[words[i].Word])
+	}
+}
+
+// mostCommonKey returns the key with the highest count (alphabetically first on ties)
+func mostCommonKey(counts map[string]int) string {
+	best, bestCount := "", 0
+	for key, count := range counts {
+		if count > bestCount || (count == bestCount && key < best) {
+			best, bestCount = key, count
+		}
+	}
+	return best
+}
+
+// DisplayWord returns the word with its most common surface form, if it differs
+func (wc WordCount) DisplayWord() string {
+	if wc.Form == "" || wc.Form == wc.Word {
+		return wc.Word
+	}
+	return fmt.Sprintf("%s (%s)", wc.Word, wc.Form)
+}
+
 // getMostActiveWindow finds the most active 2-hour window
 func getMostActiveWindow(hourly map[int]int) TimeWindow {
 	windows := []TimeWindow{
@@ -301,6 +629,21 @@ func (s *Stats) GetSortedYears() []int {
 	return years
 }
 
+// GetSortedLanguages returns language codes sorted by message count (descending)
+func GetSortedLanguages(messagesByLanguage map[string]int) []string {
+	langs := make([]string, 0, len(messagesByLanguage))
+	for lang := range messagesByLanguage {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if messagesByLanguage[langs[i]] != messagesByLanguage[langs[j]] {
+			return messagesByLanguage[langs[i]] > messagesByLanguage[langs[j]]
+		}
+		return langs[i] < langs[j]
+	})
+	return langs
+}
+
 // UserStat represents user with message count
 type UserStat struct {
 	Name  string
@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// burstWindow is the number of preceding days that form the baseline of a day
+	burstWindow = 28
+	// minBurstHistory is the minimal number of preceding days needed to judge a day
+	minBurstHistory = 7
+	// burstThreshold is the z-score above which a day is unusually busy
+	burstThreshold = 3.0
+	// minBurstMessages drops busy days of very quiet chats
+	minBurstMessages = 10
+	// burstWords is how many characteristic words describe a burst
+	burstWords = 8
+)
+
+// Burst is a run of days with unusually many messages
+type Burst struct {
+	Start    time.Time
+	End      time.Time // last day of the burst
+	Messages int
+	Expected float64     // messages expected from the preceding weeks
+	Score    float64     // highest z-score of a day in the burst
+	Users    []UserStat  // participants, most active first
+	Words    []WordScore // words characteristic of the burst compared to the whole chat
+}
+
+// Days returns the length of the burst in days
+func (b Burst) Days() int {
+	return int(b.End.Sub(b.Start).Hours()/24) + 1
+}
+
+// BurstsIn returns the bursts that started within a report period, strongest first
+func (s *Stats) BurstsIn(p Period) []Burst {
+	bursts := make([]Burst, 0)
+	for _, b := range s.Bursts {
+		if !b.Start.Before(p.Start) && b.Start.Before(p.End) {
+			bursts = append(bursts, b)
+		}
+	}
+	return bursts
+}
+
+// computeBursts finds days whose message count is far above the rolling mean of
+// the preceding weeks, merges neighbouring days and describes each burst
+func computeBursts(stats *Stats, activity map[string]map[string]int, corpus *topicCorpus) {
+	if len(activity) == 0 {
+		return
+	}
+	var firstDay, lastDay string
+	for day := range activity {
+		if firstDay == "" || day < firstDay {
+			firstDay = day
+		}
+		if day > lastDay {
+			lastDay = day
+		}
+	}
+	first, err1 := time.Parse("2006-01-02", firstDay)
+	last, err2 := time.Parse("2006-01-02", lastDay)
+	if err1 != nil || err2 != nil {
+		return
+	}
+
+	// Daily series with quiet days filled in
+	var dates []time.Time
+	var counts []float64
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
+		counts = append(counts, float64(sumCounts(activity[d.Format("2006-01-02")])))
+	}
+
+	current := -1 // index of the burst the previous busy day belongs to
+	for i, count := range counts {
+		if i < minBurstHistory {
+			continue
+		}
+		mean, sigma := baseline(counts[max(0, i-burstWindow):i])
+		z := (count - mean) / sigma
+		if z < burstThreshold || count < minBurstMessages {
+			continue
+		}
+		// Busy days at most one quiet day apart belong to the same burst
+		if current >= 0 && dates[i].Sub(stats.Bursts[current].End) <= 48*time.Hour {
+			b := &stats.Bursts[current]
+			b.Expected += mean * dates[i].Sub(b.End).Hours() / 24
+			b.End = dates[i]
+			b.Score = max(b.Score, z)
+			continue
+		}
+		stats.Bursts = append(stats.Bursts, Burst{Start: dates[i], End: dates[i], Expected: mean, Score: z})
+		current = len(stats.Bursts) - 1
+	}
+
+	prior := stats.Overall.WordFrequency
+	for i := range stats.Bursts {
+		b := &stats.Bursts[i]
+		users := make(map[string]int)
+		words := make(map[string]int)
+		for d := b.Start; !d.After(b.End); d = d.AddDate(0, 0, 1) {
+			key := d.Format("2006-01-02")
+			for user, count := range activity[key] {
+				users[user] += count
+			}
+			for word, count := range corpus.days[key] {
+				words[word] += count
+			}
+		}
+		b.Messages = sumCounts(users)
+		b.Users = GetSortedUsers(users)
+		b.Words = getSignatureWords(words, subtractFrequency(prior, words), prior, burstWords)
+		setSignatureForms(b.Words, stats.Overall.WordForms)
+	}
+
+	sort.SliceStable(stats.Bursts, func(i, j int) bool {
+		return stats.Bursts[i].Score > stats.Bursts[j].Score
+	})
+}
+
+// baseline returns the mean of daily counts and a standard deviation that is
+// never below the Poisson noise of the mean, so quiet chats do not flag every busy day
+func baseline(counts []float64) (mean, sigma float64) {
+	for _, c := range counts {
+		mean += c
+	}
+	mean /= float64(len(counts))
+	variance := 0.0
+	for _, c := range counts {
+		variance += (c - mean) * (c - mean)
+	}
+	variance /= float64(len(counts))
+	return mean, math.Max(math.Sqrt(math.Max(variance, mean)), 1)
+}
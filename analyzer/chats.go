@@ -0,0 +1,154 @@
+package analyzer
+
+import (
+	"sort"
+)
+
+const (
+	// minVocabularyCount is how many uses make a word part of a chat's vocabulary
+	minVocabularyCount = 3
+	// chatWords is how many shared and distinctive words a chat comparison keeps
+	chatWords = 20
+)
+
+// ChatComparison compares several analysed chats side by side
+type ChatComparison struct {
+	Chats           []*Stats
+	Users           []ChatUserShare // people of all chats, most active first
+	HourShares      [][24]float64   // chat -> share of messages sent in each hour
+	VocabularySizes []int           // chat -> number of words used at least minVocabularyCount times
+	Overlap         [][]float64     // Jaccard similarity of the vocabularies of two chats
+	SharedWords     []WordCount     // words in the vocabulary of every chat, Count is the total
+	DistinctWords   [][]WordCount   // chat -> frequent words missing from all other chats
+}
+
+// ChatUserShare is a person's activity in every compared chat
+type ChatUserShare struct {
+	Name     string
+	Messages []int     // chat -> messages
+	Shares   []float64 // chat -> share of the chat's messages
+}
+
+// CompareChats computes volume, hour, people and vocabulary differences of chats
+func CompareChats(chats []*Stats) *ChatComparison {
+	cmp := &ChatComparison{Chats: chats}
+
+	users := make(map[string]*ChatUserShare)
+	for i, chat := range chats {
+		for name, count := range chat.Overall.MessagesByUser {
+			if users[name] == nil {
+				users[name] = &ChatUserShare{
+					Name:     name,
+					Messages: make([]int, len(chats)),
+					Shares:   make([]float64, len(chats)),
+				}
+			}
+			users[name].Messages[i] = count
+			users[name].Shares[i] = share(count, chat.Overall.TotalMessages)
+		}
+	}
+	for _, u := range users {
+		cmp.Users = append(cmp.Users, *u)
+	}
+	total := func(u ChatUserShare) int {
+		sum := 0
+		for _, c := range u.Messages {
+			sum += c
+		}
+		return sum
+	}
+	sort.Slice(cmp.Users, func(i, j int) bool {
+		ti, tj := total(cmp.Users[i]), total(cmp.Users[j])
+		if ti != tj {
+			return ti > tj
+		}
+		return cmp.Users[i].Name < cmp.Users[j].Name
+	})
+
+	vocabularies := make([]map[string]bool, len(chats))
+	cmp.HourShares = make([][24]float64, len(chats))
+	for i, chat := range chats {
+		for hour := 0; hour < 24; hour++ {
+			cmp.HourShares[i][hour] = share(chat.Overall.HourlyActivity[hour], chat.Overall.TotalMessages)
+		}
+		vocabularies[i] = make(map[string]bool)
+		for word, count := range chat.Overall.WordFrequency {
+			if count >= minVocabularyCount {
+				vocabularies[i][word] = true
+			}
+		}
+		cmp.VocabularySizes = append(cmp.VocabularySizes, len(vocabularies[i]))
+	}
+
+	cmp.Overlap = make([][]float64, len(chats))
+	for i := range chats {
+		cmp.Overlap[i] = make([]float64, len(chats))
+		for j := range chats {
+			cmp.Overlap[i][j] = jaccard(vocabularies[i], vocabularies[j])
+		}
+	}
+
+	// Words every chat uses, ranked by total use
+	shared := make(map[string]int)
+	forms := make(map[string]map[string]int)
+	for word := range vocabularies[0] {
+		inAll := true
+		for _, vocab := range vocabularies[1:] {
+			if !vocab[word] {
+				inAll = false
+				break
+			}
+		}
+		if !inAll {
+			continue
+		}
+		for _, chat := range chats {
+			shared[word] += chat.Overall.WordFrequency[word]
+			for form, count := range chat.Overall.WordForms[word] {
+				if forms[word] == nil {
+					forms[word] = make(map[string]int)
+				}
+				forms[word][form] += count
+			}
+		}
+	}
+	cmp.SharedWords = getTopWords(shared, chatWords)
+	setWordForms(cmp.SharedWords, forms)
+
+	// Words of one chat that no other chat uses
+	for i, chat := range chats {
+		distinct := make(map[string]int)
+		for word := range vocabularies[i] {
+			missing := true
+			for j, other := range chats {
+				if j != i && other.Overall.WordFrequency[word] > 0 {
+					missing = false
+					break
+				}
+			}
+			if missing {
+				distinct[word] = chat.Overall.WordFrequency[word]
+			}
+		}
+		words := getTopWords(distinct, chatWords)
+		setWordForms(words, chat.Overall.WordForms)
+		cmp.DistinctWords = append(cmp.DistinctWords, words)
+	}
+
+	return cmp
+}
+
+// jaccard returns the size of the intersection of two sets divided by the size of their union
+func jaccard(a, b map[string]bool) float64 {
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+	union := len(a) + len(b) - common
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
@@ -0,0 +1,173 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"telegram_message_analyzer/parser"
+)
+
+const (
+	// minShiftCount is the minimal number of uses in the two periods together for a
+	// word to be compared, so that words new in one of them are compared too
+	minShiftCount = 5
+	// shiftWords is how many rising and falling words a comparison keeps
+	shiftWords = 20
+)
+
+// PeriodRange marks statistics of a custom date range
+const PeriodRange PeriodKind = "range"
+
+// Comparison describes how a chat changed from one period to another
+type Comparison struct {
+	Before       *YearStats
+	After        *YearStats
+	Users        []UserShift // users of both periods, most active first
+	RisingWords  []WordShift
+	FallingWords []WordShift
+	HourShares   [2][24]float64 // share of messages sent in each hour, before and after
+}
+
+// UserShift is a user's activity in both compared periods
+type UserShift struct {
+	Name        string
+	Before      int
+	After       int
+	BeforeShare float64
+	AfterShare  float64
+}
+
+// WordShift is a word whose relative frequency changed between periods
+type WordShift struct {
+	WordCount          // Count is the number of uses in the later period
+	Before     int     // uses in the earlier period
+	BeforeRate float64 // uses per 1000 counted words
+	AfterRate  float64
+	Change     float64 // log2 of the ratio of smoothed rates
+}
+
+// AnalyzeRange analyses only the messages sent from the first to the last day inclusive
+func AnalyzeRange(result *parser.ParseResult, opts Options, from, to time.Time) *YearStats {
+	opts.Topics = 0
+	stats := Analyze(parser.Filter{From: from, To: to}.Apply(result), opts)
+	stats.Overall.Period = Period{
+		Kind:  PeriodRange,
+		Key:   from.Format("2006-01-02") + ".." + to.Format("2006-01-02"),
+		Start: from,
+		End:   to.AddDate(0, 0, 1),
+	}
+	return &stats.Overall
+}
+
+// Compare computes volume, user, word and hour changes from before to after
+func Compare(before, after *YearStats) *Comparison {
+	cmp := &Comparison{Before: before, After: after}
+
+	users := make(map[string]bool)
+	for user := range before.MessagesByUser {
+		users[user] = true
+	}
+	for user := range after.MessagesByUser {
+		users[user] = true
+	}
+	for user := range users {
+		b, a := before.MessagesByUser[user], after.MessagesByUser[user]
+		cmp.Users = append(cmp.Users, UserShift{
+			Name:        user,
+			Before:      b,
+			After:       a,
+			BeforeShare: share(b, before.TotalMessages),
+			AfterShare:  share(a, after.TotalMessages),
+		})
+	}
+	sort.Slice(cmp.Users, func(i, j int) bool {
+		ti, tj := cmp.Users[i].Before+cmp.Users[i].After, cmp.Users[j].Before+cmp.Users[j].After
+		if ti != tj {
+			return ti > tj
+		}
+		return cmp.Users[i].Name < cmp.Users[j].Name
+	})
+
+	cmp.RisingWords, cmp.FallingWords = getWordShifts(before, after)
+
+	for i, ys := range []*YearStats{before, after} {
+		for hour := 0; hour < 24; hour++ {
+			cmp.HourShares[i][hour] = share(ys.HourlyActivity[hour], ys.TotalMessages)
+		}
+	}
+	return cmp
+}
+
+// getWordShifts ranks words by the change of their relative frequency.
+// Rates are smoothed with half a use so that words missing in one period still compare.
+func getWordShifts(before, after *YearStats) (rising, falling []WordShift) {
+	beforeTotal, afterTotal := sumCounts(before.WordFrequency), sumCounts(after.WordFrequency)
+	if beforeTotal == 0 || afterTotal == 0 {
+		return nil, nil
+	}
+
+	words := make(map[string]bool)
+	for word := range before.WordFrequency {
+		words[word] = true
+	}
+	for word := range after.WordFrequency {
+		words[word] = true
+	}
+
+	shifts := make([]WordShift, 0)
+	for word := range words {
+		b, a := before.WordFrequency[word], after.WordFrequency[word]
+		if a+b < minShiftCount {
+			continue
+		}
+		beforeRate := (float64(b) + 0.5) / float64(beforeTotal)
+		afterRate := (float64(a) + 0.5) / float64(afterTotal)
+		form := mostCommonKey(after.WordForms[word])
+		if form == "" {
+			form = mostCommonKey(before.WordForms[word])
+		}
+		shifts = append(shifts, WordShift{
+			WordCount:  WordCount{Word: word, Form: form, Count: a},
+			Before:     b,
+			BeforeRate: float64(b) / float64(beforeTotal) * 1000,
+			AfterRate:  float64(a) / float64(afterTotal) * 1000,
+			Change:     math.Log2(afterRate / beforeRate),
+		})
+	}
+
+	// Strong changes of frequent words come first
+	weight := func(s WordShift) float64 {
+		return s.Change * math.Log1p(float64(s.Count+s.Before))
+	}
+	sort.Slice(shifts, func(i, j int) bool {
+		wi, wj := weight(shifts[i]), weight(shifts[j])
+		if wi != wj {
+			return wi > wj
+		}
+		return shifts[i].Word < shifts[j].Word
+	})
+
+	for _, s := range shifts {
+		if len(rising) == shiftWords || s.Change <= 0 {
+			break
+		}
+		rising = append(rising, s)
+	}
+	for i := len(shifts) - 1; i >= 0; i-- {
+		s := shifts[i]
+		if len(falling) == shiftWords || s.Change >= 0 {
+			break
+		}
+		falling = append(falling, s)
+	}
+	return rising, falling
+}
+
+// share returns part divided by total, or 0 for an empty total
+func share(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
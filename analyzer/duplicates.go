@@ -0,0 +1,220 @@
+package analyzer
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"telegram_message_analyzer/parser"
+)
+
+const (
+	// minDuplicateLength skips short messages like "привет" that repeat naturally
+	minDuplicateLength = 30
+	// shingleSize is the number of characters in a shingle
+	shingleSize = 5
+	// minHashBands and minHashRows split MinHash signatures for locality-sensitive hashing
+	minHashBands = 16
+	minHashRows  = 4
+	// nearDuplicateSimilarity is the estimated Jaccard similarity of near-duplicates
+	nearDuplicateSimilarity = 0.7
+)
+
+// DuplicateGroup is a text sent several times, exactly or with small changes
+type DuplicateGroup struct {
+	Text     string // first occurrence
+	Messages int
+	Variants int        // number of different texts in the group
+	Senders  []UserStat // who sent the text, most often first
+	First    time.Time
+	Last     time.Time
+}
+
+// findDuplicates groups long messages that repeat exactly or almost exactly and
+// marks every message that repeats an earlier one
+func findDuplicates(messages []parser.Message) (groups []DuplicateGroup, repeat []bool) {
+	repeat = make([]bool, len(messages))
+
+	// Exact duplicates share their words, ignoring case and punctuation
+	textIDs := make(map[string]int)
+	var texts []string
+	byText := make([]int, len(messages)) // message -> text ID, -1 for short messages
+	for i, msg := range messages {
+		byText[i] = -1
+		if msg.Length < minDuplicateLength {
+			continue
+		}
+		text := strings.Join(strings.FieldsFunc(strings.ToLower(msg.Text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}), " ")
+		if text == "" {
+			continue
+		}
+		id, ok := textIDs[text]
+		if !ok {
+			id = len(texts)
+			textIDs[text] = id
+			texts = append(texts, text)
+		}
+		byText[i] = id
+	}
+
+	// Near duplicates: MinHash signatures of character shingles, compared within LSH buckets
+	parent := make([]int, len(texts))
+	for i := range parent {
+		parent[i] = i
+	}
+	signatures := make([][]uint32, len(texts))
+	for i, text := range texts {
+		signatures[i] = minHash(shingles(text))
+	}
+	for band := 0; band < minHashBands; band++ {
+		buckets := make(map[string][]int)
+		for i, sig := range signatures {
+			key := bandKey(sig[band*minHashRows : (band+1)*minHashRows])
+			for _, other := range buckets[key] {
+				if findRoot(parent, other) == findRoot(parent, i) {
+					continue
+				}
+				if signatureSimilarity(signatures[other], sig) >= nearDuplicateSimilarity {
+					unionRoots(parent, other, i)
+				}
+			}
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	// Collect groups with more than one message
+	members := make(map[int][]int) // root text -> messages
+	for i, id := range byText {
+		if id < 0 {
+			continue
+		}
+		root := findRoot(parent, id)
+		members[root] = append(members[root], i)
+	}
+	for _, msgs := range members {
+		if len(msgs) < 2 {
+			continue
+		}
+		senders := make(map[string]int)
+		variants := make(map[int]bool)
+		for n, i := range msgs {
+			senders[messages[i].From]++
+			variants[byText[i]] = true
+			repeat[i] = n > 0
+		}
+		groups = append(groups, DuplicateGroup{
+			Text:     messages[msgs[0]].Text,
+			Messages: len(msgs),
+			Variants: len(variants),
+			Senders:  GetSortedUsers(senders),
+			First:    messages[msgs[0]].Date,
+			Last:     messages[msgs[len(msgs)-1]].Date,
+		})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Messages != groups[j].Messages {
+			return groups[i].Messages > groups[j].Messages
+		}
+		return groups[i].First.Before(groups[j].First)
+	})
+	return groups, repeat
+}
+
+// shingles returns the overlapping character n-grams of a text
+func shingles(text string) []string {
+	runes := []rune(text)
+	if len(runes) <= shingleSize {
+		return []string{text}
+	}
+	result := make([]string, 0, len(runes)-shingleSize+1)
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		result = append(result, string(runes[i:i+shingleSize]))
+	}
+	return result
+}
+
+// minHash returns the MinHash signature of a set of shingles
+func minHash(shingles []string) []uint32 {
+	sig := make([]uint32, minHashBands*minHashRows)
+	for i := range sig {
+		sig[i] = ^uint32(0)
+	}
+	for _, s := range shingles {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		base := h.Sum64()
+		for i := range sig {
+			if v := uint32(mix64(base + uint64(i)*0x9e3779b97f4a7c15)); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig
+}
+
+// mix64 is the splitmix64 finalizer, used to derive independent hash functions
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// bandKey turns a band of a signature into a map key
+func bandKey(band []uint32) string {
+	buf := make([]byte, 4*len(band))
+	for i, v := range band {
+		binary.LittleEndian.PutUint32(buf[4*i:], v)
+	}
+	return string(buf)
+}
+
+// signatureSimilarity estimates the Jaccard similarity of two shingle sets
+func signatureSimilarity(a, b []uint32) float64 {
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a))
+}
+
+// findRoot returns the root of an element in a union-find forest
+func findRoot(parent []int, i int) int {
+	for parent[i] != i {
+		parent[i] = parent[parent[i]]
+		i = parent[i]
+	}
+	return i
+}
+
+// unionRoots merges the sets of two elements, keeping the smaller root
+func unionRoots(parent []int, a, b int) {
+	ra, rb := findRoot(parent, a), findRoot(parent, b)
+	if ra == rb {
+		return
+	}
+	if ra < rb {
+		parent[rb] = ra
+	} else {
+		parent[ra] = rb
+	}
+}
+
+// DuplicateMessages returns how many messages repeat an earlier one
+func (s *Stats) DuplicateMessages() int {
+	count := 0
+	for _, g := range s.Duplicates {
+		count += g.Messages - 1
+	}
+	return count
+}
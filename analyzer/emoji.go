@@ -0,0 +1,173 @@
+package analyzer
+
+import (
+	"unicode"
+)
+
+const (
+	zeroWidthJoiner = '\u200d'
+	variationText   = '\ufe0e'
+	variationEmoji  = '\ufe0f'
+	combiningKeycap = '\u20e3'
+)
+
+// emojiPresentation lists the symbols below U+1F000 that are shown as emoji
+// by default (the Unicode Emoji_Presentation property)
+var emojiPresentation = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23EC, Stride: 1},
+		{Lo: 0x23F0, Hi: 0x23F3, Stride: 3},
+		{Lo: 0x25FD, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267F, Hi: 0x2693, Stride: 0x14},
+		{Lo: 0x26A1, Hi: 0x26A1, Stride: 1},
+		{Lo: 0x26AA, Hi: 0x26AB, Stride: 1},
+		{Lo: 0x26BD, Hi: 0x26BE, Stride: 1},
+		{Lo: 0x26C4, Hi: 0x26C5, Stride: 1},
+		{Lo: 0x26CE, Hi: 0x26D4, Stride: 6},
+		{Lo: 0x26EA, Hi: 0x26EA, Stride: 1},
+		{Lo: 0x26F2, Hi: 0x26F3, Stride: 1},
+		{Lo: 0x26F5, Hi: 0x26FA, Stride: 5},
+		{Lo: 0x26FD, Hi: 0x26FD, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270A, Hi: 0x270B, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274E, Stride: 2},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27BF, Stride: 0xF},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B55, Stride: 5},
+	},
+}
+
+// isEmojiBase reports whether r is an emoji on its own, without a variation selector
+func isEmojiBase(r rune) bool {
+	if r >= 0x1F000 && r <= 0x1FAFF { // pictographs, emoticons, transport, flags, symbols
+		return !isSkinTone(r)
+	}
+	return unicode.Is(emojiPresentation, r)
+}
+
+// isEmojiSymbol reports whether r is a symbol that becomes an emoji with the
+// emoji variation selector, like "❤️", "✔️" or "©️". Without the selector these
+// are ordinary text symbols.
+func isEmojiSymbol(r rune) bool {
+	switch {
+	case isEmojiBase(r):
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // technical symbols (⌨, ⏏)
+		return true
+	case r >= 0x2B05 && r <= 0x2B55: // arrows and squares
+		return true
+	case r >= 0x2194 && r <= 0x21AA, r >= 0x25AA && r <= 0x25FE: // arrows, ▶, ◀, ◻
+		return true
+	case r == 0x00A9 || r == 0x00AE || r == 0x2122 || r == 0x2139 || r == 0x24C2:
+		return true
+	case r == 0x203C || r == 0x2049 || r == 0x3030 || r == 0x303D || r == 0x3297 || r == 0x3299:
+		return true
+	}
+	return false
+}
+
+// isSkinTone reports whether r is a Fitzpatrick skin tone modifier
+func isSkinTone(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+// isRegionalIndicator reports whether r is half of a flag emoji
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isEmojiTag reports whether r belongs to a tag sequence (subdivision flags)
+func isEmojiTag(r rune) bool {
+	return r >= 0xE0020 && r <= 0xE007F
+}
+
+// extractEmoji returns all emoji of a text. Multi-codepoint sequences (ZWJ
+// families and professions, skin tones, flags, keycaps) are kept whole.
+func extractEmoji(text string) []string {
+	runes := []rune(text)
+	var result []string
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		// Keycaps: digit, "#" or "*", optional variation selector, combining keycap
+		if unicode.IsDigit(r) || r == '#' || r == '*' {
+			j := i + 1
+			if j < len(runes) && runes[j] == variationEmoji {
+				j++
+			}
+			if j < len(runes) && runes[j] == combiningKeycap {
+				result = append(result, normalizeEmoji(runes[i:j+1]))
+				i = j + 1
+				continue
+			}
+			i++
+			continue
+		}
+
+		// Flags are pairs of regional indicators
+		if isRegionalIndicator(r) {
+			if i+1 < len(runes) && isRegionalIndicator(runes[i+1]) {
+				result = append(result, string(runes[i:i+2]))
+				i += 2
+			} else {
+				i++
+			}
+			continue
+		}
+
+		// Text symbols are emoji only when followed by the emoji variation selector
+		selected := i+1 < len(runes) && runes[i+1] == variationEmoji
+		if !isEmojiBase(r) && !(selected && isEmojiSymbol(r)) {
+			i++
+			continue
+		}
+
+		// Extend the sequence with modifiers and ZWJ-joined emoji
+		j := i + 1
+		for j < len(runes) {
+			next := runes[j]
+			if next == variationEmoji || next == variationText || isSkinTone(next) || isEmojiTag(next) {
+				j++
+			} else if next == zeroWidthJoiner && j+1 < len(runes) && isEmojiSymbol(runes[j+1]) {
+				j += 2
+			} else {
+				break
+			}
+		}
+		result = append(result, normalizeEmoji(runes[i:j]))
+		i = j
+	}
+
+	return result
+}
+
+// normalizeEmoji makes "❤" and "❤️" count as the same emoji: a lone symbol
+// always gets the emoji variation selector, longer sequences are kept as written
+func normalizeEmoji(seq []rune) string {
+	runes := make([]rune, 0, len(seq)+1)
+	for _, r := range seq {
+		if r != variationText {
+			runes = append(runes, r)
+		}
+	}
+	if len(runes) == 2 && runes[1] == variationEmoji {
+		runes = runes[:1]
+	}
+	switch {
+	case len(runes) == 1 && runes[0] < 0x1F000:
+		runes = append(runes, variationEmoji)
+	case len(runes) == 2 && runes[1] == combiningKeycap:
+		runes = []rune{runes[0], variationEmoji, combiningKeycap}
+	}
+	return string(runes)
+}
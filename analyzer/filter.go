@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"sort"
+
+	"telegram_message_analyzer/stopwords"
+)
+
+// wordFilter decides which words are excluded from frequency counts
+type wordFilter struct {
+	listed map[string]bool // user-supplied stop words and ignore lists
+	extra  map[string]bool // listed words and their normalised forms
+	keep   map[string]bool // words never treated as stop words
+}
+
+// newWordFilter builds a filter from user-supplied entries. Entries are split
+// into words the same way as messages, so "/start" or "Иван Петров" match too.
+func newWordFilter(opts Options) *wordFilter {
+	f := &wordFilter{
+		listed: make(map[string]bool),
+		extra:  make(map[string]bool),
+		keep:   make(map[string]bool),
+	}
+	for _, entry := range opts.StopWords {
+		for _, word := range extractWords(entry) {
+			f.listed[word] = true
+			f.extra[word] = true
+			f.extra[normalizeWord(word, opts.Normalization)] = true
+		}
+	}
+	for _, entry := range opts.KeepWords {
+		for _, word := range extractWords(entry) {
+			f.keep[word] = true
+		}
+	}
+	return f
+}
+
+// isStopWord checks the surface form and its normalised word against all lists.
+// Laughter variants are checked as the one word they are counted as, so that
+// "хах" and "ахахахаха" are either both excluded or both kept.
+func (f *wordFilter) isStopWord(lang, form, word string) bool {
+	if _, ok := laughWord(form); ok {
+		form = word
+	}
+	if f.extra[form] || f.extra[word] {
+		return true
+	}
+	return !f.keep[form] && stopwords.IsStopWordIn(lang, form)
+}
+
+// sortedKeys returns the words of a set in alphabetical order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
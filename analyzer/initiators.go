@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"time"
+
+	"telegram_message_analyzer/parser"
+)
+
+// DefaultInitiatorSilence is the silence after which a message starts a new conversation
+const DefaultInitiatorSilence = 6 * time.Hour
+
+// Initiations counts the conversations a person started
+type Initiations struct {
+	Count    int
+	Hours    [24]int        // hour -> conversations started
+	Weekdays [7]int         // time.Weekday -> conversations started
+	Months   map[string]int // "YYYY-MM" -> conversations started
+}
+
+// FavoriteHour returns the hour a person starts conversations most often
+func (in *Initiations) FavoriteHour() int {
+	best := 0
+	for h, count := range in.Hours {
+		if count > in.Hours[best] {
+			best = h
+		}
+	}
+	return best
+}
+
+// FavoriteWeekday returns the day of the week a person starts conversations most often
+func (in *Initiations) FavoriteWeekday() time.Weekday {
+	best := time.Monday
+	for i := 1; i <= 7; i++ {
+		if d := time.Weekday(i % 7); in.Weekdays[d] > in.Weekdays[best] {
+			best = d
+		}
+	}
+	return best
+}
+
+// initiatorTracker finds messages sent after a long silence. The first message
+// of the export is not counted because the silence before it is unknown.
+type initiatorTracker struct {
+	silence time.Duration
+	last    time.Time
+}
+
+// add processes the next message of the chat
+func (t *initiatorTracker) add(msg parser.Message, targets []*YearStats) {
+	if !t.last.IsZero() && msg.Date.Sub(t.last) > t.silence {
+		for _, ys := range targets {
+			ys.addInitiation(msg)
+		}
+	}
+	t.last = msg.Date
+}
+
+// addInitiation records a conversation started by a message
+func (ys *YearStats) addInitiation(msg parser.Message) {
+	in := ys.InitiationsByUser[msg.From]
+	if in == nil {
+		in = &Initiations{Months: make(map[string]int)}
+		ys.InitiationsByUser[msg.From] = in
+	}
+	ys.Conversations++
+	in.Count++
+	in.Hours[msg.Date.Hour()]++
+	in.Weekdays[msg.Date.Weekday()]++
+	in.Months[msg.Date.Format("2006-01")]++
+}
+
+// InitiationShare returns the share of conversations a person started
+func (ys *YearStats) InitiationShare(user string) float64 {
+	in := ys.InitiationsByUser[user]
+	if in == nil || ys.Conversations == 0 {
+		return 0
+	}
+	return float64(in.Count) / float64(ys.Conversations)
+}
+
+// InitiationsInMonth returns how many conversations were started in a month
+func (ys *YearStats) InitiationsInMonth(month string) int {
+	total := 0
+	for _, in := range ys.InitiationsByUser {
+		total += in.Months[month]
+	}
+	return total
+}
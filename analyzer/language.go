@@ -0,0 +1,189 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+
+	"telegram_message_analyzer/stopwords"
+)
+
+// Language codes reported by DetectLanguage
+const (
+	LangRussian   = "ru"
+	LangUkrainian = "uk"
+	LangEnglish   = "en"
+	LangUnknown   = "und"
+)
+
+// minLanguageLetters is the shortest message (in letters) we try to classify
+const minLanguageLetters = 8
+
+// languageSamples are small training texts used to build n-gram profiles
+var languageSamples = map[string]string{
+	LangRussian: `Привет, как дела? Я сегодня весь день работал, а вечером пошёл гулять с друзьями.
+		Мы долго сидели в кафе и разговаривали о том, куда поехать летом. Кто-то хочет на море,
+		кто-то в горы, а мне всё равно, лишь бы вместе. Завтра надо встать пораньше, потому что
+		у меня встреча в девять утра. Ты не забыл, что в субботу день рождения у сестры? Нужно
+		купить подарок, может быть книгу или что-нибудь для дома. Погода сегодня отличная, солнце
+		светит, совсем не хочется сидеть в офисе. Вчера смотрели новый фильм, он мне очень понравился,
+		хотя конец был немного странный. Напиши, когда освободишься, созвонимся и всё обсудим.
+		Спасибо большое за помощь, без тебя я бы точно не справился. Давай встретимся после работы
+		возле метро, оттуда пойдём пешком. Если что, звони, я буду дома весь вечер. Сколько стоит
+		билет на поезд? Кажется, цены опять выросли. Мы ещё не решили, что будем делать на выходных.
+		Объясни, пожалуйста, почему это происходит, я уже ничего не понимаю. Съешь ещё этих мягких
+		французских булок да выпей же чаю. Хорошие новости: наконец-то нашли квартиру рядом с парком.
+		Какие у тебя планы на вечер? Старые друзья приехали в гости, будем готовить ужин и играть
+		в настольные игры. Вечером было холодно, поэтому мы вернулись домой рано. Новая работа мне
+		нравится, коллеги хорошие, только дорога длинная. Купил продукты, молоко, хлеб и сыр.`,
+	LangUkrainian: `Привіт, як справи? Я сьогодні цілий день працював, а ввечері пішов гуляти з друзями.
+		Ми довго сиділи в кав'ярні і розмовляли про те, куди поїхати влітку. Хтось хоче на море,
+		хтось у гори, а мені все одно, аби разом. Завтра треба встати раніше, бо в мене зустріч
+		о дев'ятій ранку. Ти не забув, що в суботу день народження у сестри? Потрібно купити
+		подарунок, можливо книжку або щось для дому. Погода сьогодні чудова, сонце світить, зовсім
+		не хочеться сидіти в офісі. Вчора дивилися новий фільм, він мені дуже сподобався, хоча
+		кінець був трохи дивний. Напиши, коли звільнишся, зателефонуємо і все обговоримо. Дякую
+		за допомогу, без тебе я б точно не впорався. Давай зустрінемося після роботи біля метро,
+		звідти підемо пішки. Якщо що, дзвони, я буду вдома весь вечір. Скільки коштує квиток на
+		потяг? Здається, ціни знову зросли. Ми ще не вирішили, що будемо робити на вихідних.
+		Поясни, будь ласка, чому це відбувається, я вже нічого не розумію. Їжак ґудзик єнот їхав
+		через ґанок і шукав своє яблуко. Гарні новини: нарешті знайшли квартиру поруч із парком.
+		Які в тебе плани на вечір? Старі друзі приїхали в гості, будемо готувати вечерю і грати
+		в настільні ігри. Увечері було холодно, тому ми повернулися додому рано. Нова робота мені
+		подобається, колеги хороші, тільки дорога довга. Купив продукти, молоко, хліб і сир.`,
+	LangEnglish: `Hi, how are you doing? I was working all day today and in the evening I went out with
+		friends. We sat in a cafe for a long time and talked about where to go this summer. Someone
+		wants to go to the sea, someone to the mountains, and I don't really care as long as we are
+		together. Tomorrow I need to get up early because I have a meeting at nine in the morning.
+		Did you remember that it's my sister's birthday on Saturday? We should buy a present, maybe
+		a book or something for the house. The weather is great today, the sun is shining and I don't
+		want to sit in the office at all. Yesterday we watched a new movie, I really liked it, although
+		the ending was a little strange. Text me when you're free, we'll call and discuss everything.
+		Thank you so much for your help, I couldn't have done it without you. Let's meet after work
+		near the station and walk from there. If anything happens, call me, I'll be at home all
+		evening. How much is a train ticket? It seems that prices went up again. We still haven't
+		decided what we're doing at the weekend. Please explain why this is happening, I don't
+		understand anything anymore. The quick brown fox jumps over the lazy dog.`,
+}
+
+// languageProfile holds log-probabilities of character n-grams for one language
+type languageProfile struct {
+	logProb  map[string]float64
+	fallback float64
+}
+
+var (
+	languageProfiles     map[string]*languageProfile
+	languageProfilesOnce sync.Once
+)
+
+// loadLanguageProfiles builds n-gram profiles from the bundled samples
+func loadLanguageProfiles() {
+	languageProfiles = make(map[string]*languageProfile, len(languageSamples))
+	for lang, sample := range languageSamples {
+		counts := make(map[string]int)
+		total := 0
+		for _, g := range charNGrams(sample) {
+			counts[g]++
+			total++
+		}
+		vocab := float64(len(counts) + 1)
+		profile := &languageProfile{
+			logProb:  make(map[string]float64, len(counts)),
+			fallback: math.Log(1 / (float64(total) + vocab)),
+		}
+		for g, c := range counts {
+			profile.logProb[g] = math.Log((float64(c) + 1) / (float64(total) + vocab))
+		}
+		languageProfiles[lang] = profile
+	}
+}
+
+// charNGrams returns character 1-3 grams of every word, padded with spaces
+func charNGrams(text string) []string {
+	var grams []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	}) {
+		runes := []rune(" " + word + " ")
+		for n := 1; n <= 3; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				if n == 1 && runes[i] == ' ' {
+					continue
+				}
+				grams = append(grams, string(runes[i:i+n]))
+			}
+		}
+	}
+	return grams
+}
+
+// DetectLanguage guesses the language of a message using character n-grams.
+// Short messages and texts in unsupported scripts return LangUnknown.
+func DetectLanguage(text string) string {
+	letters, latin, cyrillic := 0, 0, 0
+	russianOnly, ukrainianOnly := false, false
+	for _, r := range strings.ToLower(text) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			russianOnly = russianOnly || strings.ContainsRune("ыэъё", r)
+			ukrainianOnly = ukrainianOnly || strings.ContainsRune("іїєґ", r)
+		}
+	}
+	if letters < minLanguageLetters || latin+cyrillic < letters/2 {
+		return LangUnknown
+	}
+
+	// Letters and function words unique to one language settle mostly-Cyrillic messages
+	if cyrillic > latin {
+		switch {
+		case ukrainianOnly && !russianOnly:
+			return LangUkrainian
+		case russianOnly && !ukrainianOnly:
+			return LangRussian
+		}
+		ru, uk := 0, 0
+		for _, word := range extractWords(text) {
+			inRu, inUk := stopwords.Russian[word], stopwords.Ukrainian[word]
+			if inRu && !inUk {
+				ru++
+			} else if inUk && !inRu {
+				uk++
+			}
+		}
+		switch {
+		case uk > ru:
+			return LangUkrainian
+		case ru > uk:
+			return LangRussian
+		}
+	}
+
+	languageProfilesOnce.Do(loadLanguageProfiles)
+
+	grams := charNGrams(text)
+	best, bestScore := LangUnknown, math.Inf(-1)
+	for _, lang := range []string{LangRussian, LangUkrainian, LangEnglish} {
+		profile := languageProfiles[lang]
+		score := 0.0
+		for _, g := range grams {
+			if p, ok := profile.logProb[g]; ok {
+				score += p
+			} else {
+				score += profile.fallback
+			}
+		}
+		if score > bestScore {
+			best, bestScore = lang, score
+		}
+	}
+	return best
+}
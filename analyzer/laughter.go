@@ -0,0 +1,123 @@
+package analyzer
+
+import (
+	"regexp"
+	"time"
+
+	"telegram_message_analyzer/parser"
+)
+
+const (
+	// laughRu and laughEn are the words all laughter variants are counted as
+	laughRu = "ахаха"
+	laughEn = "haha"
+)
+
+// laughWords are reactions that mean laughter but do not look like "хаха"
+var laughWords = map[string]bool{
+	"лол": true, "ору": true, "ржу": true, "ржака": true, "угар": true,
+	"lol": true, "lmao": true, "lmfao": true, "rofl": true,
+}
+
+// laughEmoticon matches "))" and longer bracket smiles, ":D", "xD" and "хД"
+var laughEmoticon = regexp.MustCompile(`\){2,}|(?:^|[\s:;])[:;xXхХ]-?[DДд](?:$|[\s!.,)])`)
+
+// laughWord reports whether a lowercased word is a laughter variant like "ахах",
+// "хахахаха", "хехе" or "hahaha" and returns the word it is counted as
+func laughWord(word string) (string, bool) {
+	runes := []rune(word)
+	if len(runes) < 3 {
+		return "", false
+	}
+	laughs, vowels := 0, 0
+	latin := false
+	for _, r := range runes {
+		switch r {
+		case 'х':
+			laughs++
+		case 'h':
+			laughs++
+			latin = true
+		case 'а', 'е', 'и', 'ы', 'a', 'e', 'i':
+			vowels++
+		default:
+			return "", false
+		}
+	}
+	// "аха" is agreement, "хах" and "ахах" are laughter
+	if laughs < 2 || vowels == 0 {
+		return "", false
+	}
+	if latin {
+		return laughEn, true
+	}
+	return laughRu, true
+}
+
+// containsLaughter reports whether a message laughs
+func containsLaughter(text string) bool {
+	for _, word := range extractWords(text) {
+		if _, ok := laughWord(word); ok || laughWords[word] {
+			return true
+		}
+	}
+	return laughEmoticon.MatchString(text)
+}
+
+// laughTracker credits laughs to the author of the message they react to: the
+// replied message when the laugh replies to someone else, otherwise the latest
+// message of another person in the same conversation
+type laughTracker struct {
+	authors map[int]string // message ID -> author
+	last    sentMessage    // latest message
+	other   sentMessage    // latest message of someone other than the author of last
+}
+
+// sentMessage is the author and time of a message
+type sentMessage struct {
+	from string
+	date time.Time
+}
+
+func newLaughTracker() *laughTracker {
+	return &laughTracker{authors: make(map[int]string)}
+}
+
+// add processes the next message of the chat
+func (t *laughTracker) add(msg parser.Message, targets []*YearStats) {
+	if containsLaughter(msg.Text) {
+		trigger := ""
+		if author, ok := t.authors[msg.ReplyTo]; ok && msg.ReplyTo != 0 && author != msg.From {
+			trigger = author
+		} else {
+			prev := t.last
+			if prev.from == msg.From {
+				prev = t.other
+			}
+			if prev.from != "" && msg.Date.Sub(prev.date) <= sessionGap {
+				trigger = prev.from
+			}
+		}
+		for _, ys := range targets {
+			ys.LaughsByUser[msg.From]++
+			if trigger != "" {
+				ys.LaughTriggersByUser[trigger]++
+				incNested(ys.LaughedAtByUser, msg.From, trigger)
+			}
+		}
+	}
+
+	if msg.From != t.last.from {
+		t.other = t.last
+	}
+	t.last = sentMessage{from: msg.From, date: msg.Date}
+	if msg.ID != 0 {
+		t.authors[msg.ID] = msg.From
+	}
+}
+
+// FunniestFor returns whose messages make a person laugh most often
+func (ys *YearStats) FunniestFor(user string) (string, int) {
+	name := mostCommonKey(ys.LaughedAtByUser[user])
+	return name, ys.LaughedAtByUser[user][name]
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"telegram_message_analyzer/parser"
+)
+
+// lengthBuckets are the upper bounds (in characters) of the length histogram buckets
+var lengthBuckets = []int{10, 30, 50, 100, 200, 500, 1000}
+
+// LengthStats describes the distribution of message lengths in characters
+type LengthStats struct {
+	Messages int
+	Median   int
+	P90      int
+	P99      int
+	Max      int
+}
+
+// LengthBucket is a range of message lengths with the number of messages in it
+type LengthBucket struct {
+	Min   int
+	Max   int // 0 means no upper bound
+	Count int
+}
+
+// LongMessage is a message kept as an example, e.g. the longest one of a user
+type LongMessage struct {
+	Text   string
+	Length int
+	Date   time.Time
+}
+
+// addLength records the length and word count of a message
+func (ys *YearStats) addLength(msg parser.Message) {
+	ys.MessageLengths = append(ys.MessageLengths, msg.Length)
+	ys.MessageLengthsByUser[msg.From] = append(ys.MessageLengthsByUser[msg.From], msg.Length)
+	ys.WordCountByUser[msg.From] += len(strings.Fields(msg.Text))
+	if longest, ok := ys.LongestMessageByUser[msg.From]; !ok || msg.Length > longest.Length {
+		ys.LongestMessageByUser[msg.From] = LongMessage{Text: msg.Text, Length: msg.Length, Date: msg.Date}
+	}
+}
+
+// getLengthStats computes the median, percentiles and maximum of message lengths
+func getLengthStats(lengths []int) LengthStats {
+	if len(lengths) == 0 {
+		return LengthStats{}
+	}
+	sorted := append([]int(nil), lengths...)
+	sort.Ints(sorted)
+	return LengthStats{
+		Messages: len(sorted),
+		Median:   percentile(sorted, 0.5),
+		P90:      percentile(sorted, 0.9),
+		P99:      percentile(sorted, 0.99),
+		Max:      sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []int, p float64) int {
+	rank := int(p*float64(len(sorted))+0.999999) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// getLengthHistogram counts messages in the length buckets
+func getLengthHistogram(lengths []int) []LengthBucket {
+	buckets := make([]LengthBucket, len(lengthBuckets)+1)
+	low := 1
+	for i, high := range lengthBuckets {
+		buckets[i] = LengthBucket{Min: low, Max: high}
+		low = high + 1
+	}
+	buckets[len(lengthBuckets)] = LengthBucket{Min: low}
+
+	for _, length := range lengths {
+		i := sort.SearchInts(lengthBuckets, length)
+		buckets[i].Count++
+	}
+	return buckets
+}
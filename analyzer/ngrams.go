@@ -0,0 +1,148 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// minPhraseCount is the minimal number of occurrences for a phrase to be ranked
+const minPhraseCount = 3
+
+// PhraseCount represents a 2- or 3-word phrase with its collocation score
+type PhraseCount struct {
+	Phrase string  // space-separated (normalised) words
+	Form   string  // phrase built from the most common surface forms
+	Count  int     // occurrences
+	Score  float64 // Dunning log-likelihood ratio (G²)
+}
+
+// DisplayPhrase returns the phrase as it is most commonly written
+func (pc PhraseCount) DisplayPhrase() string {
+	if pc.Form != "" {
+		return pc.Form
+	}
+	return pc.Phrase
+}
+
+// addPhrases counts all n-grams of the given sizes in a sequence of words
+func addPhrases(freq map[string]int, words []string, sizes ...int) {
+	for _, n := range sizes {
+		for i := 0; i+n <= len(words); i++ {
+			freq[strings.Join(words[i:i+n], " ")]++
+		}
+	}
+}
+
+// getTopPhrases ranks n-word phrases by log-likelihood. A phrase is split into
+// a prefix of n-1 words and its last word, and the score measures how much more
+// often they appear together than expected if they were independent.
+func getTopPhrases(freq map[string]int, n, limit int) []PhraseCount {
+	prefixCount := make(map[string]int)
+	suffixCount := make(map[string]int)
+	total := 0
+	for phrase, count := range freq {
+		if strings.Count(phrase, " ") != n-1 {
+			continue
+		}
+		prefix, suffix := splitPhrase(phrase)
+		prefixCount[prefix] += count
+		suffixCount[suffix] += count
+		total += count
+	}
+
+	phrases := make([]PhraseCount, 0)
+	for phrase, count := range freq {
+		if count < minPhraseCount || strings.Count(phrase, " ") != n-1 {
+			continue
+		}
+		prefix, suffix := splitPhrase(phrase)
+		k11 := float64(count)
+		k12 := float64(prefixCount[prefix] - count)
+		k21 := float64(suffixCount[suffix] - count)
+		k22 := float64(total) - k11 - k12 - k21
+
+		// Skip pairs that occur together less often than by chance
+		expected := float64(prefixCount[prefix]) * float64(suffixCount[suffix]) / float64(total)
+		if k11 <= expected {
+			continue
+		}
+
+		phrases = append(phrases, PhraseCount{
+			Phrase: phrase,
+			Count:  count,
+			Score:  logLikelihood(k11, k12, k21, k22),
+		})
+	}
+
+	sort.Slice(phrases, func(i, j int) bool {
+		if phrases[i].Score != phrases[j].Score {
+			return phrases[i].Score > phrases[j].Score
+		}
+		return phrases[i].Phrase < phrases[j].Phrase
+	})
+
+	if len(phrases) > limit {
+		phrases = phrases[:limit]
+	}
+	return phrases
+}
+
+// getTopPhrasesMixed ranks bigrams and trigrams together
+func getTopPhrasesMixed(freq map[string]int, limit int) []PhraseCount {
+	phrases := append(getTopPhrases(freq, 2, limit), getTopPhrases(freq, 3, limit)...)
+	sort.Slice(phrases, func(i, j int) bool {
+		if phrases[i].Score != phrases[j].Score {
+			return phrases[i].Score > phrases[j].Score
+		}
+		return phrases[i].Phrase < phrases[j].Phrase
+	})
+	if len(phrases) > limit {
+		phrases = phrases[:limit]
+	}
+	return phrases
+}
+
+// splitPhrase splits a phrase into all words but the last one and the last word
+func splitPhrase(phrase string) (string, string) {
+	i := strings.LastIndex(phrase, " ")
+	return phrase[:i], phrase[i+1:]
+}
+
+// logLikelihood computes Dunning's G² statistic for a 2x2 contingency table
+func logLikelihood(k11, k12, k21, k22 float64) float64 {
+	n := k11 + k12 + k21 + k22
+	if n == 0 {
+		return 0
+	}
+	row1, row2 := k11+k12, k21+k22
+	col1, col2 := k11+k21, k12+k22
+	g := 0.0
+	for _, c := range []struct{ observed, expected float64 }{
+		{k11, row1 * col1 / n},
+		{k12, row1 * col2 / n},
+		{k21, row2 * col1 / n},
+		{k22, row2 * col2 / n},
+	} {
+		if c.observed > 0 && c.expected > 0 {
+			g += c.observed * math.Log(c.observed/c.expected)
+		}
+	}
+	return 2 * g
+}
+
+// setPhraseForms fills Form using the most common surface form of each word
+func setPhraseForms(phrases []PhraseCount, forms map[string]map[string]int) {
+	if len(forms) == 0 {
+		return
+	}
+	for i := range phrases {
+		words := strings.Fields(phrases[i].Phrase)
+		for j, word := range words {
+			if form := mostCommonKey(forms[word]); form != "" {
+				words[j] = form
+			}
+		}
+		phrases[i].Form = strings.Join(words, " ")
+	}
+}
@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PeriodKind is the length of the periods reports are split into
+type PeriodKind string
+
+const (
+	PeriodYear    PeriodKind = "year"
+	PeriodQuarter PeriodKind = "quarter"
+	PeriodMonth   PeriodKind = "month"
+	PeriodWeek    PeriodKind = "week"
+)
+
+// ParsePeriodKind converts a command line value to a period kind
+func ParsePeriodKind(value string) (PeriodKind, bool) {
+	switch kind := PeriodKind(strings.ToLower(value)); kind {
+	case PeriodYear, PeriodQuarter, PeriodMonth, PeriodWeek:
+		return kind, true
+	}
+	return "", false
+}
+
+// Period is a time span covered by one report
+type Period struct {
+	Kind  PeriodKind
+	Key   string    // sortable identifier: "2023", "2023/24", "2023-Q2", "2023/24-Q1", "2023-05", "2023-W07"
+	Start time.Time // first moment of the period
+	End   time.Time // first moment after the period
+}
+
+// PeriodOf returns the period containing t. yearStart is the first month of years
+// and quarters: time.January for calendar years, time.September for academic years,
+// or the first month of a fiscal year. Years that do not start in January are
+// named after both calendar years they span, like "2023/24".
+func PeriodOf(t time.Time, kind PeriodKind, yearStart time.Month) Period {
+	switch kind {
+	case PeriodMonth:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return Period{Kind: kind, Key: t.Format("2006-01"), Start: start, End: start.AddDate(0, 1, 0)}
+
+	case PeriodWeek:
+		year, week := t.ISOWeek()
+		monday := (int(t.Weekday()) + 6) % 7
+		start := time.Date(t.Year(), t.Month(), t.Day()-monday, 0, 0, 0, 0, t.Location())
+		return Period{Kind: kind, Key: fmt.Sprintf("%d-W%02d", year, week), Start: start, End: start.AddDate(0, 0, 7)}
+	}
+
+	if yearStart < time.January || yearStart > time.December {
+		yearStart = time.January
+	}
+	year := t.Year()
+	if t.Month() < yearStart {
+		year--
+	}
+	start := time.Date(year, yearStart, 1, 0, 0, 0, 0, t.Location())
+	key := fmt.Sprintf("%d", year)
+	if yearStart != time.January {
+		key = fmt.Sprintf("%d/%02d", year, (year+1)%100)
+	}
+
+	if kind == PeriodQuarter {
+		quarter := (int(t.Month())-int(yearStart)+12)%12/3 + 1
+		start = start.AddDate(0, 3*(quarter-1), 0)
+		return Period{Kind: kind, Key: fmt.Sprintf("%s-Q%d", key, quarter), Start: start, End: start.AddDate(0, 3, 0)}
+	}
+	return Period{Kind: PeriodYear, Key: key, Start: start, End: start.AddDate(1, 0, 0)}
+}
+
+// calendarYears reports whether report periods are plain calendar years,
+// in which case ByPeriod shares its statistics with ByYear
+func (s *Stats) calendarYears() bool {
+	return s.PeriodKind == PeriodYear && s.YearStart == time.January
+}
+
+// GetSortedPeriods returns period keys in chronological order
+func (s *Stats) GetSortedPeriods() []string {
+	keys := make([]string, 0, len(s.ByPeriod))
+	for key := range s.ByPeriod {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,152 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// matPrefixes are verb prefixes that turn mat roots into derived words
+// ("нахуй", "заебал", "отъебись"). "с" is left out so "себе" is not matched.
+const matPrefixes = `(?:а|вы|до|за|из|изъ|на|не|недо|ни|о|об|объ|от|отъ|по|под|подъ|пере|при|про|раз|разъ|рас|съ|у|вз|взъ)?`
+
+// russianProfanity matches Russian mat and obscene insults after deobfuscation
+var russianProfanity = regexp.MustCompile(`^(?:` + strings.Join([]string{
+	matPrefixes + `ху[йяеюи]`,
+	matPrefixes + `пи[зс]д`,
+	matPrefixes + `еб(?:а|у|л|н|и|е|ы|о|ш|$)`,
+	matPrefixes + `дроч`,
+	`(?:вы)?бля`,
+	`муд(?:а[кч]|ил|оз|ох)`,
+	`пид[оае]?р`,
+	`су(?:ка|ки|ке|ку|кой|ками|чка|чки|чар|чий|чье|чья|чьи)$`,
+	`залуп`,
+	`г[ао]ндон`,
+	`шлюх`,
+	`манд(?:а|ы|е|у|ой)$`,
+}, "|") + `)`)
+
+// latinProfanity matches English profanity and transliterated Russian mat
+var latinProfanity = regexp.MustCompile(`^(?:` + strings.Join([]string{
+	`\pL*f+u*c+k`,
+	`(?:wtf|stfu)$`,
+	`(?:bull|horse)?s+h+i+t`,
+	`b+i+t+c+h`,
+	`a+s+s+h+o+l+e`,
+	`c+u+n+t+s?$`,
+	`d+i+c+k+(?:head|s)?$`,
+	`bastard`,
+	`(?:god)?damn`,
+	`bl[yj]a`,
+	`(?:na|po|o)?h+u+[iyj]`,
+	`pi[zs]d`,
+	`s+u+k+a+$`,
+}, "|") + `)`)
+
+// profanityToken matches words including "*" and "@" used to hide letters
+var profanityToken = regexp.MustCompile(`[\pL\pN*@]+`)
+
+// cyrillicLookalikes replace Latin letters and digits that stand in for Cyrillic ones
+var cyrillicLookalikes = strings.NewReplacer(
+	"a", "а", "c", "с", "e", "е", "k", "к", "m", "м", "o", "о", "p", "р", "x", "х", "y", "у",
+	"0", "о", "3", "з", "4", "ч", "6", "б", "@", "а", "ё", "е",
+)
+
+// latinLookalikes replace digits that stand in for Latin letters
+var latinLookalikes = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "@", "a")
+
+const (
+	// wildcardLetters are tried in place of "*" in words like "х*й" or "f*ck"
+	wildcardLetters = "уиеаояюлдйuioae"
+	// maxWildcards limits how many hidden letters a word may have
+	maxWildcards = 2
+)
+
+// profaneWord returns the deobfuscated form of a word if it is obscene
+func profaneWord(token string) (string, bool) {
+	word := deobfuscate(strings.ToLower(token))
+	if !strings.Contains(word, "*") {
+		if isProfane(word) {
+			return word, true
+		}
+		// Stretched letters: "бляяя", "сууука"
+		word = collapseRepeats(word)
+		return word, isProfane(word)
+	}
+	// Hidden letters: try every candidate in place of the first asterisk
+	if strings.Count(word, "*") > maxWildcards || strings.Trim(word, "*") == "" {
+		return "", false
+	}
+	for _, r := range wildcardLetters {
+		candidate := strings.Replace(word, "*", string(r), 1)
+		if found, ok := profaneWord(candidate); ok {
+			return found, true
+		}
+	}
+	return "", false
+}
+
+// deobfuscate undoes letter substitutions like "xуй", "6ля" or "sh1t"
+func deobfuscate(word string) string {
+	cyrillic := false
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			cyrillic = true
+			break
+		}
+	}
+	if !cyrillic {
+		return latinLookalikes.Replace(word)
+	}
+	return cyrillicLookalikes.Replace(word)
+}
+
+// collapseRepeats replaces runs of the same letter with a single letter
+func collapseRepeats(word string) string {
+	var sb strings.Builder
+	var prev rune
+	for _, r := range word {
+		if r != prev {
+			sb.WriteRune(r)
+		}
+		prev = r
+	}
+	return sb.String()
+}
+
+// isProfane checks a deobfuscated word against the obscene word patterns
+func isProfane(word string) bool {
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return russianProfanity.MatchString(word)
+		}
+	}
+	return latinProfanity.MatchString(word)
+}
+
+// findProfanity returns the obscene words of a text in their deobfuscated form
+func findProfanity(text string) []string {
+	var words []string
+	for _, token := range profanityToken.FindAllString(text, -1) {
+		if word, ok := profaneWord(token); ok {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// MaskProfanity replaces all but the first letter of every obscene word with "*"
+func MaskProfanity(text string) string {
+	return profanityToken.ReplaceAllStringFunc(text, func(token string) string {
+		if _, ok := profaneWord(token); !ok {
+			return token
+		}
+		runes := []rune(token)
+		return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	})
+}
+
+// ProfanityTotal returns the number of obscene words in the period
+func (ys *YearStats) ProfanityTotal() int {
+	return sumCounts(ys.ProfanityFrequency)
+}
@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"telegram_message_analyzer/parser"
+)
+
+// sessionGap is the silence after which a conversation is considered over
+const sessionGap = time.Hour
+
+// questionWords start questions that are written without a question mark
+var questionWords = map[string]bool{
+	// Russian
+	"кто": true, "что": true, "где": true, "когда": true, "почему": true, "зачем": true,
+	"как": true, "какой": true, "какая": true, "какое": true, "какие": true, "каким": true,
+	"сколько": true, "куда": true, "откуда": true, "чей": true, "чья": true, "чье": true,
+	"чьи": true, "разве": true, "неужели": true,
+	// English
+	"who": true, "what": true, "where": true, "when": true, "why": true, "how": true,
+	"which": true, "whose": true, "whom": true,
+}
+
+// IsQuestion reports whether a message ends with a question mark or starts with
+// an interrogative word
+func IsQuestion(text string) bool {
+	trimmed := strings.TrimRightFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ')' || r == '(' || r == '.' || r == '!' ||
+			isEmojiSymbol(r) || isSkinTone(r) || r == variationEmoji || r == zeroWidthJoiner
+	})
+	if strings.HasSuffix(trimmed, "?") {
+		return true
+	}
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-'
+	})
+	if len(fields) == 0 {
+		return false
+	}
+	// "что-то", "как-нибудь" are not questions
+	if strings.Contains(fields[0], "-") {
+		return false
+	}
+	return questionWords[strings.ReplaceAll(fields[0], "ё", "е")] ||
+		(len(fields) > 1 && fields[1] == "ли")
+}
+
+// pendingQuestion is a question that has not been answered yet
+type pendingQuestion struct {
+	from    string
+	date    time.Time
+	targets []*YearStats // statistics the question is counted in
+}
+
+// questionTracker matches questions with answers. An explicit reply to a question
+// answers it at any time; otherwise the first message of another person in the
+// same session, including a reply to something else, answers all open questions.
+type questionTracker struct {
+	open []*pendingQuestion
+	byID map[int]*pendingQuestion
+	last time.Time
+}
+
+func newQuestionTracker() *questionTracker {
+	return &questionTracker{byID: make(map[int]*pendingQuestion)}
+}
+
+// add processes the next message of the chat
+func (t *questionTracker) add(msg parser.Message, targets []*YearStats) {
+	if !t.last.IsZero() && msg.Date.Sub(t.last) > sessionGap {
+		t.open = nil
+	}
+	t.last = msg.Date
+
+	if q, ok := t.byID[msg.ReplyTo]; ok && msg.ReplyTo != 0 {
+		if q.from != msg.From {
+			t.answer(q, msg.Date)
+		}
+	} else {
+		// Replies to other messages answer like any next message
+		for _, q := range t.open {
+			if q.from != msg.From {
+				t.answer(q, msg.Date)
+			}
+		}
+	}
+	// Answered questions are dropped from the open list
+	open := t.open[:0]
+	for _, q := range t.open {
+		if q.targets != nil {
+			open = append(open, q)
+		}
+	}
+	t.open = open
+
+	if !IsQuestion(msg.Text) {
+		return
+	}
+	for _, ys := range targets {
+		ys.QuestionsByUser[msg.From]++
+	}
+	q := &pendingQuestion{from: msg.From, date: msg.Date, targets: targets}
+	t.open = append(t.open, q)
+	if msg.ID != 0 {
+		t.byID[msg.ID] = q
+	}
+}
+
+// answer records the time it took to answer a question
+func (t *questionTracker) answer(q *pendingQuestion, at time.Time) {
+	if q.targets == nil {
+		return
+	}
+	for _, ys := range q.targets {
+		ys.AnsweredByUser[q.from]++
+		ys.AnswerTimeByUser[q.from] += at.Sub(q.date)
+	}
+	q.targets = nil
+}
+
+// AvgAnswerTime returns how long a person waits for an answer to a question on average
+func (ys *YearStats) AvgAnswerTime(user string) time.Duration {
+	if ys.AnsweredByUser[user] == 0 {
+		return 0
+	}
+	return ys.AnswerTimeByUser[user] / time.Duration(ys.AnsweredByUser[user])
+}
+
+// QuestionTotals returns how many questions were asked and answered in total
+func (ys *YearStats) QuestionTotals() (asked, answered int) {
+	return sumCounts(ys.QuestionsByUser), sumCounts(ys.AnsweredByUser)
+}
@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"time"
+
+	"telegram_message_analyzer/parser"
+)
+
+// MessageRun is a series of consecutive messages sent by one person
+type MessageRun struct {
+	From     string
+	Start    time.Time
+	End      time.Time
+	Messages int
+}
+
+// runTracker groups consecutive messages of the same sender into runs
+type runTracker struct {
+	run     MessageRun
+	targets []*YearStats // statistics the run is counted in, taken from its first message
+}
+
+// add extends the current run or finishes it when the sender changes or the
+// conversation pauses for longer than sessionGap
+func (t *runTracker) add(msg parser.Message, targets []*YearStats) {
+	if t.run.Messages > 0 && msg.From == t.run.From && msg.Date.Sub(t.run.End) <= sessionGap {
+		t.run.End = msg.Date
+		t.run.Messages++
+		return
+	}
+	t.flush()
+	t.run = MessageRun{From: msg.From, Start: msg.Date, End: msg.Date, Messages: 1}
+	t.targets = targets
+}
+
+// flush counts the current run in its statistics
+func (t *runTracker) flush() {
+	if t.run.Messages == 0 {
+		return
+	}
+	for _, ys := range t.targets {
+		ys.addRun(t.run)
+	}
+	t.run = MessageRun{}
+	t.targets = nil
+}
+
+// addRun records a run of messages of one person
+func (ys *YearStats) addRun(run MessageRun) {
+	ys.Runs++
+	ys.RunMessages += run.Messages
+	ys.RunsByUser[run.From]++
+	ys.RunMessagesByUser[run.From] += run.Messages
+	if run.Messages > 1 {
+		ys.FollowUpsByUser[run.From]++
+	}
+	if run.Messages > ys.LongestRun.Messages {
+		ys.LongestRun = run
+	}
+	if run.Messages > ys.LongestRunByUser[run.From].Messages {
+		ys.LongestRunByUser[run.From] = run
+	}
+}
+
+// AvgRunLength returns the average number of messages a person sends in a row
+func (ys *YearStats) AvgRunLength(user string) float64 {
+	if ys.RunsByUser[user] == 0 {
+		return 0
+	}
+	return float64(ys.RunMessagesByUser[user]) / float64(ys.RunsByUser[user])
+}
+
+// FollowUpRate returns how often a person writes again before anyone replies
+func (ys *YearStats) FollowUpRate(user string) float64 {
+	if ys.RunsByUser[user] == 0 {
+		return 0
+	}
+	return float64(ys.FollowUpsByUser[user]) / float64(ys.RunsByUser[user])
+}
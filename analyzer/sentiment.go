@@ -0,0 +1,213 @@
+package analyzer
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// negationWindow is how many words after a negation have their polarity inverted
+	negationWindow = 3
+	// negationFactor scales inverted polarity ("не плохо" is milder than "хорошо")
+	negationFactor = -0.75
+	// sentimentAlpha controls how fast the summed polarity approaches ±1
+	sentimentAlpha = 4.0
+	// minDayMessages is the minimal number of messages for a day to be ranked by mood
+	minDayMessages = 5
+	// minPolarityStem is the shortest stem matched against inflected forms
+	minPolarityStem = 4
+)
+
+var (
+	positiveSmiley = regexp.MustCompile(`[:;]-?\)|\){2,}|:D|xD|XD`)
+	negativeSmiley = regexp.MustCompile(`:-?\(|\({2,}`)
+)
+
+var (
+	polarityLexicon     map[string]float64 // stems of Russian words
+	polarityForms       map[string]float64 // words matched as written
+	polarityLexiconOnce sync.Once
+)
+
+// SentimentStat accumulates message sentiment scores
+type SentimentStat struct {
+	Sum      float64
+	Messages int
+	Positive int
+	Negative int
+}
+
+// Average returns the mean sentiment score from -1 (negative) to 1 (positive)
+func (s *SentimentStat) Average() float64 {
+	if s == nil || s.Messages == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Messages)
+}
+
+// add records the score of one message
+func (s *SentimentStat) add(score float64) {
+	s.Sum += score
+	s.Messages++
+	if score > 0.05 {
+		s.Positive++
+	} else if score < -0.05 {
+		s.Negative++
+	}
+}
+
+// DaySentiment represents the mood of a single day
+type DaySentiment struct {
+	Date     time.Time
+	Score    float64
+	Messages int
+}
+
+// loadPolarityLexicon merges Russian (stemmed) and English word lists
+func loadPolarityLexicon() {
+	polarityLexicon = make(map[string]float64, len(russianPolarity))
+	polarityForms = make(map[string]float64, len(russianPolarity)+len(russianPolarityForms)+len(englishPolarity))
+	for word, polarity := range russianPolarity {
+		polarityForms[word] = polarity
+		stem := StemRussian(word)
+		if len([]rune(stem)) < minPolarityStem {
+			continue
+		}
+		// Different words may share a stem; keep the stronger polarity
+		if old, ok := polarityLexicon[stem]; !ok || math.Abs(polarity) > math.Abs(old) ||
+			(math.Abs(polarity) == math.Abs(old) && polarity > old) {
+			polarityLexicon[stem] = polarity
+		}
+	}
+	for word, polarity := range russianPolarityForms {
+		polarityForms[word] = polarity
+	}
+	for word, polarity := range englishPolarity {
+		polarityForms[word] = polarity
+	}
+}
+
+// wordPolarity looks a word up as written, then by its stem
+func wordPolarity(word string) (float64, bool) {
+	if p, ok := polarityForms[word]; ok {
+		return p, true
+	}
+	if polarityExclusions[word] {
+		return 0, false
+	}
+	p, ok := polarityLexicon[StemRussian(word)]
+	return p, ok
+}
+
+// ScoreSentiment returns the sentiment of a message from -1 (negative) to 1 (positive).
+// Negations flip the polarity of the next few words, intensifiers strengthen
+// the next word, and emoji and bracket smileys add their own polarity.
+func ScoreSentiment(text string) float64 {
+	polarityLexiconOnce.Do(loadPolarityLexicon)
+
+	text = strings.NewReplacer("'", "", "’", "").Replace(text)
+	words := extractWords(text)
+
+	sum := 0.0
+	negatedUntil := -1
+	multiplier := 1.0
+	for i, word := range words {
+		if negations[word] {
+			negatedUntil = i + negationWindow
+			continue
+		}
+		if m, ok := intensifiers[word]; ok && i+1 < len(words) {
+			if _, next := wordPolarity(words[i+1]); next {
+				multiplier = m
+				continue
+			}
+		}
+
+		polarity, ok := wordPolarity(word)
+		if !ok {
+			continue
+		}
+		polarity *= multiplier
+		multiplier = 1
+		if i <= negatedUntil {
+			polarity *= negationFactor
+		}
+		sum += polarity
+	}
+
+	for _, emoji := range extractEmoji(text) {
+		sum += emojiPolarity[emoji]
+	}
+	sum += 0.5 * float64(len(positiveSmiley.FindAllString(text, -1)))
+	if endsWithBracketSmiley(text) {
+		sum += 0.5
+	}
+	sum -= 0.5 * float64(len(negativeSmiley.FindAllString(text, -1)))
+
+	if sum == 0 {
+		return 0
+	}
+	return sum / math.Sqrt(sum*sum+sentimentAlpha)
+}
+
+// endsWithBracketSmiley reports whether a message ends in a single ")" that
+// closes no "(", like "привет)", unlike "(см. выше)"
+func endsWithBracketSmiley(text string) bool {
+	text = strings.TrimSpace(positiveSmiley.ReplaceAllString(text, ""))
+	return strings.HasSuffix(text, ")") && strings.Count(text, ")") > strings.Count(text, "(")
+}
+
+// addSentiment records a message score in the period, month, day and user aggregates
+func (ys *YearStats) addSentiment(user string, date time.Time, score float64) {
+	ys.Sentiment.add(score)
+	getSentiment(ys.SentimentByMonth, date.Format("2006-01")).add(score)
+	getSentiment(ys.SentimentByDay, date.Format("2006-01-02")).add(score)
+	getSentiment(ys.SentimentByUser, user).add(score)
+	if ys.SentimentByUserMonth[user] == nil {
+		ys.SentimentByUserMonth[user] = make(map[string]*SentimentStat)
+	}
+	getSentiment(ys.SentimentByUserMonth[user], date.Format("2006-01")).add(score)
+}
+
+// getSentiment returns the accumulator for a key, creating it if needed
+func getSentiment(m map[string]*SentimentStat, key string) *SentimentStat {
+	if m[key] == nil {
+		m[key] = &SentimentStat{}
+	}
+	return m[key]
+}
+
+// getMoodiestDays returns the n days with the highest (or lowest) average sentiment
+func getMoodiestDays(byDay map[string]*SentimentStat, n int, positive bool) []DaySentiment {
+	days := make([]DaySentiment, 0, len(byDay))
+	for key, s := range byDay {
+		if s.Messages < minDayMessages {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", key)
+		if err != nil {
+			continue
+		}
+		if score := s.Average(); (positive && score > 0) || (!positive && score < 0) {
+			days = append(days, DaySentiment{Date: date, Score: score, Messages: s.Messages})
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Score != days[j].Score {
+			if positive {
+				return days[i].Score > days[j].Score
+			}
+			return days[i].Score < days[j].Score
+		}
+		return days[i].Date.Before(days[j].Date)
+	})
+	if len(days) > n {
+		days = days[:n]
+	}
+	return days
+}
@@ -0,0 +1,113 @@
+package analyzer
+
+// russianPolarity lists Russian words in dictionary form with polarity from -1 to 1.
+// Words are stemmed when the lexicon is loaded, so inflected forms match too,
+// unless the stem is too short to tell the word from unrelated ones.
+var russianPolarity = map[string]float64{
+	// Положительные
+	"хороший": 0.6, "хорошо": 0.6, "отличный": 0.9, "отлично": 0.9, "прекрасный": 0.9,
+	"прекрасно": 0.9, "замечательный": 0.9, "замечательно": 0.9, "классный": 0.8, "классно": 0.8,
+	"круто": 0.8, "крутой": 0.7, "супер": 0.8, "шикарный": 0.9, "шикарно": 0.9, "офигенно": 0.9,
+	"офигенный": 0.9, "кайф": 0.8, "кайфовый": 0.8, "обалденный": 0.9, "чудесный": 0.8,
+	"великолепный": 0.9, "идеальный": 0.8, "идеально": 0.8, "восторг": 0.9, "восхитительный": 0.9,
+	"любить": 0.7, "любовь": 0.8, "люблю": 0.8, "нравиться": 0.6, "обожать": 0.8, "радость": 0.8,
+	"радоваться": 0.7, "рад": 0.7, "рада": 0.7, "счастье": 0.9, "счастливый": 0.9, "весело": 0.6,
+	"веселый": 0.6, "смешно": 0.5, "смешной": 0.5, "спасибо": 0.6, "благодарить": 0.6,
+	"поздравлять": 0.7, "поздравление": 0.6, "ура": 0.8, "молодец": 0.8, "умница": 0.8,
+	"красивый": 0.6, "красиво": 0.6, "милый": 0.6, "мило": 0.6, "вкусный": 0.6, "вкусно": 0.6,
+	"интересный": 0.5, "интересно": 0.5, "удобный": 0.4, "удобно": 0.4, "приятный": 0.6,
+	"приятно": 0.6, "добрый": 0.5, "друг": 0.3, "успех": 0.7, "успешный": 0.6, "победа": 0.7,
+	"получиться": 0.5, "повезти": 0.6, "удача": 0.7, "лучший": 0.7, "лучше": 0.4, "ок": 0.2,
+	"наконец": 0.3, "праздник": 0.5, "отпуск": 0.4, "улыбка": 0.6, "обнимать": 0.6, "целовать": 0.6,
+	"гордиться": 0.6, "восхищаться": 0.7, "вау": 0.6, "обнять": 0.6, "довольный": 0.6,
+	"спокойный": 0.3, "уютный": 0.5, "надежда": 0.4, "поддержка": 0.5, "помощь": 0.4,
+
+	// Отрицательные
+	"плохой": -0.6, "плохо": -0.6, "ужасный": -0.9, "ужасно": -0.9, "ужас": -0.8, "кошмар": -0.8,
+	"отвратительный": -0.9, "отвратительно": -0.9, "мерзкий": -0.8, "противный": -0.6,
+	"грустный": -0.6, "грустно": -0.6, "печальный": -0.6, "печально": -0.6, "тоска": -0.6,
+	"скучно": -0.4, "скучный": -0.4, "устать": -0.4, "усталый": -0.4, "болеть": -0.5,
+	"больно": -0.6, "боль": -0.6, "болезнь": -0.6, "злой": -0.6, "злиться": -0.6, "бесить": -0.7,
+	"раздражать": -0.6, "ненавидеть": -0.9, "ненависть": -0.9, "обидно": -0.6, "обида": -0.6,
+	"обидеть": -0.6, "жаль": -0.4, "жалко": -0.4, "сожалеть": -0.5, "проблема": -0.5,
+	"беда": -0.7, "провал": -0.7, "провалить": -0.7, "ошибка": -0.4, "сломаться": -0.6,
+	"сломать": -0.5, "потерять": -0.5, "опоздать": -0.4, "страшно": -0.6, "страшный": -0.6,
+	"бояться": -0.5, "страх": -0.6, "тревога": -0.6, "тревожно": -0.6, "волноваться": -0.4,
+	"плакать": -0.6, "слезы": -0.5, "депрессия": -0.8, "одиноко": -0.6, "одиночество": -0.6,
+	"дурак": -0.6, "идиот": -0.7, "тупой": -0.6, "глупый": -0.5, "бред": -0.5, "отстой": -0.7,
+	"фигня": -0.5, "хрень": -0.6, "жесть": -0.5, "пиздец": -0.7, "херня": -0.6, "дерьмо": -0.8,
+	"говно": -0.8, "сука": -0.6, "блин": -0.3, "разочарование": -0.7, "разочаровать": -0.6,
+	"неудача": -0.6, "хуже": -0.5, "худший": -0.7, "мучиться": -0.6, "дождь": -0.1,
+	"холодно": -0.2, "экзамен": -0.1, "умереть": -0.7, "смерть": -0.8, "война": -0.8,
+	"конфликт": -0.5, "ссора": -0.6, "поссориться": -0.6, "увольнять": -0.6, "штраф": -0.5,
+}
+
+// russianPolarityForms lists inflected forms of words whose stems are too short
+// to be matched safely ("рад" would also match "ради" and "радио")
+var russianPolarityForms = map[string]float64{
+	"рады": 0.7, "радует": 0.6, "радуюсь": 0.7, "любит": 0.7, "любишь": 0.7, "любят": 0.7,
+	"любил": 0.7, "любила": 0.7, "милая": 0.6, "милое": 0.6, "милые": 0.6, "окей": 0.2,
+	"беды": -0.7, "бедой": -0.7, "бесит": -0.7, "бесят": -0.7, "боли": -0.6, "болит": -0.5,
+	"боюсь": -0.5, "боится": -0.5, "злая": -0.6, "злые": -0.6, "злюсь": -0.6, "тупая": -0.6,
+	"тупые": -0.6, "тупо": -0.5, "суки": -0.6, "мучаюсь": -0.6,
+}
+
+// polarityExclusions are words whose stems coincide with lexicon stems although
+// the words are unrelated ("другой" is not "друг", "крутить" is not "крутой").
+// Forms shared with the related word, like "другом", are not excluded.
+var polarityExclusions = map[string]bool{
+	"другой": true, "другая": true, "другое": true, "другие": true, "другого": true,
+	"другому": true, "другим": true, "других": true, "другую": true,
+	"крутить": true, "крутит": true, "крутится": true, "крутят": true, "кручу": true,
+	"крутил": true, "жест": true, "жесты": true, "жестом": true, "жеста": true,
+	"слезть": true, "слезай": true, "слезь": true,
+}
+
+// englishPolarity lists English words with polarity from -1 to 1
+var englishPolarity = map[string]float64{
+	// Positive
+	"good": 0.6, "great": 0.8, "excellent": 0.9, "amazing": 0.9, "awesome": 0.9, "wonderful": 0.9,
+	"fantastic": 0.9, "perfect": 0.8, "nice": 0.6, "cool": 0.6, "love": 0.8, "loved": 0.8,
+	"lovely": 0.7, "like": 0.3, "liked": 0.4, "happy": 0.8, "glad": 0.6, "fun": 0.6, "funny": 0.5,
+	"thanks": 0.6, "thank": 0.6, "best": 0.7, "better": 0.4, "beautiful": 0.7, "cute": 0.6,
+	"yay": 0.8, "congrats": 0.8, "congratulations": 0.8, "win": 0.6, "won": 0.6, "success": 0.7,
+	"enjoy": 0.6, "enjoyed": 0.6, "delicious": 0.7, "brilliant": 0.8, "excited": 0.7, "wow": 0.6,
+	"sweet": 0.5, "proud": 0.6, "lucky": 0.6, "yes": 0.2, "ok": 0.2,
+
+	// Negative
+	"bad": -0.6, "terrible": -0.9, "awful": -0.9, "horrible": -0.9, "worst": -0.8, "worse": -0.6,
+	"hate": -0.9, "hated": -0.9, "sad": -0.6, "angry": -0.7, "annoying": -0.6, "annoyed": -0.6,
+	"boring": -0.4, "bored": -0.4, "tired": -0.4, "sick": -0.5, "pain": -0.6, "hurt": -0.6,
+	"sorry": -0.3, "problem": -0.5, "fail": -0.7, "failed": -0.7, "broken": -0.6, "broke": -0.5,
+	"lost": -0.5, "late": -0.3, "scared": -0.6, "afraid": -0.5, "worried": -0.5, "cry": -0.6,
+	"stupid": -0.6, "idiot": -0.7, "dumb": -0.6, "sucks": -0.7, "shit": -0.7, "crap": -0.6,
+	"damn": -0.4, "ugh": -0.5, "disappointed": -0.7, "lonely": -0.6, "depressed": -0.8,
+	"dead": -0.6, "die": -0.7, "wrong": -0.4, "mess": -0.5, "rain": -0.1,
+}
+
+// emojiPolarity lists emoji with polarity from -1 to 1
+var emojiPolarity = map[string]float64{
+	"😀": 0.7, "😃": 0.7, "😄": 0.7, "😁": 0.7, "😆": 0.7, "😅": 0.4, "🤣": 0.8, "😂": 0.7,
+	"🙂": 0.4, "😊": 0.7, "😇": 0.6, "🥰": 0.9, "😍": 0.9, "🤩": 0.9, "😘": 0.8, "😗": 0.5,
+	"😋": 0.6, "😎": 0.6, "🤗": 0.7, "🥳": 0.9, "😌": 0.4, "☺️": 0.6, "❤️": 0.8, "🧡": 0.7,
+	"💛": 0.7, "💚": 0.7, "💙": 0.7, "💜": 0.7, "💕": 0.8, "💖": 0.8, "💗": 0.8, "💯": 0.6,
+	"👍": 0.5, "👏": 0.6, "🙌": 0.7, "🎉": 0.8, "🔥": 0.5, "✨": 0.5, "🌞": 0.5, "💪": 0.5,
+	"😐": -0.1, "😑": -0.2, "🙄": -0.4, "😒": -0.5, "😔": -0.6, "😕": -0.4, "🙁": -0.5,
+	"☹️": -0.6, "😟": -0.5, "😞": -0.6, "😢": -0.7, "😭": -0.7, "😩": -0.6, "😫": -0.6,
+	"😤": -0.5, "😠": -0.7, "😡": -0.8, "🤬": -0.9, "😱": -0.6, "😨": -0.6, "😰": -0.6,
+	"😥": -0.5, "🤢": -0.7, "🤮": -0.8, "💔": -0.8, "👎": -0.6, "💩": -0.6, "🤦": -0.4,
+}
+
+// negations invert the polarity of the following words
+var negations = map[string]bool{
+	"не": true, "нет": true, "ни": true, "никогда": true, "нисколько": true, "вовсе": true,
+	"not": true, "no": true, "never": true, "dont": true, "didnt": true, "isnt": true,
+	"wasnt": true, "cant": true, "wont": true, "doesnt": true, "nothing": true,
+}
+
+// intensifiers strengthen the polarity of the following word
+var intensifiers = map[string]float64{
+	"очень": 1.5, "так": 1.3, "такой": 1.3, "самый": 1.4, "совсем": 1.3, "просто": 1.2,
+	"капец": 1.5, "ужасно": 1.5, "безумно": 1.6, "дико": 1.5, "реально": 1.3,
+	"very": 1.5, "so": 1.3, "really": 1.4, "extremely": 1.7, "totally": 1.4, "super": 1.5,
+}
@@ -0,0 +1,135 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// signaturePrior is the total weight of the Dirichlet prior built from overall frequencies
+	signaturePrior = 500.0
+	// minSignatureCount is the minimal number of uses for a word to be called characteristic
+	minSignatureCount = 3
+)
+
+// WordScore represents a word with its count and how characteristic it is
+type WordScore struct {
+	WordCount
+	Score float64 // z-score of the log-odds ratio against the rest of the chat
+}
+
+// computeSignatures fills signature words of every user, every year and every report period
+func computeSignatures(stats *Stats) {
+	prior := stats.Overall.WordFrequency
+
+	setUserSignatures(&stats.Overall, prior)
+	years := make([]*YearStats, 0, len(stats.ByYear))
+	for _, ys := range stats.ByYear {
+		setUserSignatures(ys, prior)
+		years = append(years, ys)
+	}
+	setPeriodSignatures(years, prior)
+
+	if stats.calendarYears() {
+		return
+	}
+	periods := make([]*YearStats, 0, len(stats.ByPeriod))
+	for _, ps := range stats.ByPeriod {
+		setUserSignatures(ps, prior)
+		periods = append(periods, ps)
+	}
+	setPeriodSignatures(periods, prior)
+}
+
+// setPeriodSignatures compares every period with all other periods,
+// which only makes sense with several periods
+func setPeriodSignatures(periods []*YearStats, prior map[string]int) {
+	if len(periods) < 2 {
+		return
+	}
+	for _, ps := range periods {
+		rest := subtractFrequency(prior, ps.WordFrequency)
+		ps.SignatureWords = getSignatureWords(ps.WordFrequency, rest, prior, 20)
+		setSignatureForms(ps.SignatureWords, ps.WordForms)
+	}
+}
+
+// setUserSignatures compares every user's words with the words of everyone else
+func setUserSignatures(ys *YearStats, prior map[string]int) {
+	ys.SignatureWordsByUser = make(map[string][]WordScore)
+	for user, freq := range ys.WordFrequencyByUser {
+		rest := subtractFrequency(ys.WordFrequency, freq)
+		ys.SignatureWordsByUser[user] = getSignatureWords(freq, rest, prior, 20)
+		setSignatureForms(ys.SignatureWordsByUser[user], ys.WordForms)
+	}
+}
+
+// subtractFrequency returns total minus part for every word of total
+func subtractFrequency(total, part map[string]int) map[string]int {
+	rest := make(map[string]int, len(total))
+	for word, count := range total {
+		if c := count - part[word]; c > 0 {
+			rest[word] = c
+		}
+	}
+	return rest
+}
+
+// getSignatureWords ranks words of target by the weighted log-odds ratio with an
+// informative Dirichlet prior (Monroe et al., "Fightin' Words"), which favours
+// words used much more often by target than by rest while damping rare words.
+func getSignatureWords(target, rest, prior map[string]int, n int) []WordScore {
+	targetTotal, restTotal, priorTotal := sumCounts(target), sumCounts(rest), sumCounts(prior)
+	if targetTotal == 0 || restTotal == 0 || priorTotal == 0 {
+		return nil
+	}
+
+	scores := make([]WordScore, 0)
+	for word, count := range target {
+		if count < minSignatureCount {
+			continue
+		}
+		alpha := signaturePrior * float64(prior[word]) / float64(priorTotal)
+		if alpha == 0 {
+			continue
+		}
+		yt, yr := float64(count), float64(rest[word])
+		nt, nr := float64(targetTotal), float64(restTotal)
+
+		delta := math.Log((yt+alpha)/(nt+signaturePrior-yt-alpha)) -
+			math.Log((yr+alpha)/(nr+signaturePrior-yr-alpha))
+		variance := 1/(yt+alpha) + 1/(yr+alpha)
+		z := delta / math.Sqrt(variance)
+		if z <= 0 {
+			continue
+		}
+		scores = append(scores, WordScore{WordCount: WordCount{Word: word, Count: count}, Score: z})
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Word < scores[j].Word
+	})
+	if len(scores) > n {
+		scores = scores[:n]
+	}
+	return scores
+}
+
+// setSignatureForms fills Form with the most common surface form of each word
+func setSignatureForms(words []WordScore, forms map[string]map[string]int) {
+	for i := range words {
+		words[i].Form = mostCommonKey(forms[words[i].Word])
+	}
+}
+
+// sumCounts returns the total of all counts in a frequency map
+func sumCounts(freq map[string]int) int {
+	total := 0
+	for _, c := range freq {
+		total += c
+	}
+	return total
+}
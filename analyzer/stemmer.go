@@ -0,0 +1,204 @@
+package analyzer
+
+import (
+	"strings"
+)
+
+// Normalization selects how words are reduced before counting
+type Normalization string
+
+const (
+	// NormalizeNone counts words as they appear (lowercased only)
+	NormalizeNone Normalization = "none"
+	// NormalizeStem reduces Russian words to their Snowball stem
+	NormalizeStem Normalization = "stem"
+)
+
+// ParseNormalization converts a flag value to Normalization
+func ParseNormalization(s string) (Normalization, bool) {
+	switch Normalization(strings.ToLower(strings.TrimSpace(s))) {
+	case NormalizeNone, "":
+		return NormalizeNone, true
+	case NormalizeStem:
+		return NormalizeStem, true
+	}
+	return NormalizeNone, false
+}
+
+// normalizeWord applies the selected normalization to a lowercased word.
+// Laughter variants are always counted as one word.
+func normalizeWord(word string, mode Normalization) string {
+	if laugh, ok := laughWord(word); ok {
+		return laugh
+	}
+	if mode == NormalizeStem {
+		return StemRussian(word)
+	}
+	return word
+}
+
+// Suffix groups of the Snowball Russian stemmer.
+// Endings in "*Group1" are removed only when preceded by "а" or "я".
+var (
+	perfectiveGerundGroup1 = []string{"в", "вши", "вшись"}
+	perfectiveGerundGroup2 = []string{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"}
+
+	adjectiveEndings = []string{
+		"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+	}
+
+	participleGroup1 = []string{"ем", "нн", "вш", "ющ", "щ"}
+	participleGroup2 = []string{"ивш", "ывш", "ующ"}
+
+	reflexiveEndings = []string{"ся", "сь"}
+
+	verbGroup1 = []string{
+		"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно",
+	}
+	verbGroup2 = []string{
+		"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
+	}
+
+	nounEndings = []string{
+		"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий",
+		"й", "иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю",
+		"ия", "ья", "я",
+	}
+
+	superlativeEndings  = []string{"ейше", "ейш"}
+	derivationalEndings = []string{"ость", "ост"}
+)
+
+// StemRussian returns the Snowball stem of a lowercased Russian word.
+// Words without Cyrillic vowels are returned unchanged.
+func StemRussian(word string) string {
+	w := []rune(strings.ReplaceAll(word, "ё", "е"))
+	rv := findRV(w)
+	if rv >= len(w) {
+		return string(w)
+	}
+	r2 := findR2(w)
+
+	// Step 1
+	if n, ok := matchGrouped(w, rv, perfectiveGerundGroup1, perfectiveGerundGroup2); ok {
+		w = w[:len(w)-n]
+	} else {
+		if n, ok := matchSuffix(w, rv, reflexiveEndings); ok {
+			w = w[:len(w)-n]
+		}
+		if n, ok := matchAdjectival(w, rv); ok {
+			w = w[:len(w)-n]
+		} else if n, ok := matchGrouped(w, rv, verbGroup1, verbGroup2); ok {
+			w = w[:len(w)-n]
+		} else if n, ok := matchSuffix(w, rv, nounEndings); ok {
+			w = w[:len(w)-n]
+		}
+	}
+
+	// Step 2
+	if len(w) > rv && w[len(w)-1] == 'и' {
+		w = w[:len(w)-1]
+	}
+
+	// Step 3
+	if n, ok := matchSuffix(w, r2, derivationalEndings); ok {
+		w = w[:len(w)-n]
+	}
+
+	// Step 4
+	if hasSuffix(w, rv, "нн") {
+		w = w[:len(w)-1]
+	} else if n, ok := matchSuffix(w, rv, superlativeEndings); ok {
+		w = w[:len(w)-n]
+		if hasSuffix(w, rv, "нн") {
+			w = w[:len(w)-1]
+		}
+	} else if len(w) > rv && w[len(w)-1] == 'ь' {
+		w = w[:len(w)-1]
+	}
+
+	return string(w)
+}
+
+// isRussianVowel reports whether r is a Russian vowel
+func isRussianVowel(r rune) bool {
+	return strings.ContainsRune("аеиоуыэюя", r)
+}
+
+// findRV returns the start of the region after the first vowel
+func findRV(w []rune) int {
+	for i, r := range w {
+		if isRussianVowel(r) {
+			return i + 1
+		}
+	}
+	return len(w)
+}
+
+// findR2 returns the start of the R2 region used by the derivational step
+func findR2(w []rune) int {
+	r1 := nextRegion(w, 0)
+	return nextRegion(w, r1)
+}
+
+// nextRegion returns the position after the first non-vowel following a vowel
+func nextRegion(w []rune, start int) int {
+	for i := start + 1; i < len(w); i++ {
+		if !isRussianVowel(w[i]) && isRussianVowel(w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(w)
+}
+
+// hasSuffix checks that w ends with suffix lying entirely inside [region:]
+func hasSuffix(w []rune, region int, suffix string) bool {
+	s := []rune(suffix)
+	if len(w)-len(s) < region {
+		return false
+	}
+	return string(w[len(w)-len(s):]) == suffix
+}
+
+// matchSuffix returns the length of the longest suffix from the list found in the region
+func matchSuffix(w []rune, region int, suffixes []string) (int, bool) {
+	best := 0
+	for _, s := range suffixes {
+		n := len([]rune(s))
+		if n > best && hasSuffix(w, region, s) {
+			best = n
+		}
+	}
+	return best, best > 0
+}
+
+// matchGrouped matches the longest suffix from both groups; group1 endings
+// must be preceded by "а" or "я", which is kept in the stem
+func matchGrouped(w []rune, region int, group1, group2 []string) (int, bool) {
+	n1, ok1 := matchSuffix(w, region, group1)
+	n2, ok2 := matchSuffix(w, region, group2)
+	if ok2 && n2 >= n1 {
+		return n2, true
+	}
+	if ok1 {
+		pos := len(w) - n1 - 1
+		if pos >= region && (w[pos] == 'а' || w[pos] == 'я') {
+			return n1, true
+		}
+	}
+	return 0, false
+}
+
+// matchAdjectival matches an adjective ending optionally preceded by a participle suffix
+func matchAdjectival(w []rune, region int) (int, bool) {
+	n, ok := matchSuffix(w, region, adjectiveEndings)
+	if !ok {
+		return 0, false
+	}
+	if p, ok := matchGrouped(w[:len(w)-n], region, participleGroup1, participleGroup2); ok {
+		n += p
+	}
+	return n, true
+}
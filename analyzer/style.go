@@ -0,0 +1,281 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// minCapsLetters is how many letters a message needs to count as written in caps
+	minCapsLetters = 5
+	// minTypoBase is how often a word must be used to be the correct spelling of a typo
+	minTypoBase = 10
+	// maxTypoCount is how often a misspelling may occur before it is a spelling of its own
+	maxTypoCount = 2
+	// minTypoLength skips short words where one letter changes the word
+	minTypoLength = 4
+	// userTypos is how many typos are kept for each user
+	userTypos = 5
+)
+
+// StyleStats accumulates writing habits of a user
+type StyleStats struct {
+	Messages       int
+	LetterStarts   int // messages starting with a letter
+	Capitalized    int // messages starting with a capital letter
+	AllCaps        int // messages written in capitals only
+	EndPunctuation int // messages ending with ".", "!", "?" or "…"
+	Commas         int
+	Exclamations   int
+	QuestionMarks  int
+	Ellipses       int
+	Smileys        int // messages with ")" smileys
+	Sentences      int
+	SentenceWords  int
+	Latin          int // Latin letters
+	Cyrillic       int // Cyrillic letters
+}
+
+// Typo is a rare spelling of a frequent word
+type Typo struct {
+	Word    string
+	Correct string
+	Count   int
+}
+
+// Share returns part divided by the number of messages
+func (s *StyleStats) Share(part int) float64 {
+	if s == nil || s.Messages == 0 {
+		return 0
+	}
+	return float64(part) / float64(s.Messages)
+}
+
+// CapitalizedShare returns the share of messages starting with a capital letter
+func (s *StyleStats) CapitalizedShare() float64 {
+	if s == nil || s.LetterStarts == 0 {
+		return 0
+	}
+	return float64(s.Capitalized) / float64(s.LetterStarts)
+}
+
+// SentenceLength returns the average number of words in a sentence
+func (s *StyleStats) SentenceLength() float64 {
+	if s == nil || s.Sentences == 0 {
+		return 0
+	}
+	return float64(s.SentenceWords) / float64(s.Sentences)
+}
+
+// LatinShare returns the share of Latin letters among Latin and Cyrillic letters
+func (s *StyleStats) LatinShare() float64 {
+	if s == nil || s.Latin+s.Cyrillic == 0 {
+		return 0
+	}
+	return float64(s.Latin) / float64(s.Latin+s.Cyrillic)
+}
+
+// addStyle records the writing habits seen in a message
+func (ys *YearStats) addStyle(user, text string) {
+	s := ys.StyleByUser[user]
+	if s == nil {
+		s = &StyleStats{}
+		ys.StyleByUser[user] = s
+	}
+	s.Messages++
+
+	letters, upper := 0, 0
+	first := true
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if first {
+			s.LetterStarts++
+			if unicode.IsUpper(r) {
+				s.Capitalized++
+			}
+			first = false
+		}
+		letters++
+		if unicode.IsUpper(r) {
+			upper++
+		}
+		if unicode.Is(unicode.Latin, r) {
+			s.Latin++
+		} else if unicode.Is(unicode.Cyrillic, r) {
+			s.Cyrillic++
+		}
+	}
+	if letters >= minCapsLetters && upper == letters {
+		s.AllCaps++
+	}
+
+	trimmed := strings.TrimRightFunc(text, unicode.IsSpace)
+	if strings.HasSuffix(trimmed, "...") || strings.HasSuffix(trimmed, "…") ||
+		strings.HasSuffix(trimmed, ".") || strings.HasSuffix(trimmed, "!") || strings.HasSuffix(trimmed, "?") {
+		s.EndPunctuation++
+	}
+	s.Commas += strings.Count(text, ",")
+	s.Exclamations += strings.Count(text, "!")
+	s.QuestionMarks += strings.Count(text, "?")
+	s.Ellipses += strings.Count(text, "...") + strings.Count(text, "…")
+	// More closing than opening brackets means smileys, not parentheses
+	if strings.Count(text, ")") > strings.Count(text, "(") {
+		s.Smileys++
+	}
+
+	for _, sentence := range strings.FieldsFunc(text, func(r rune) bool {
+		return r == '.' || r == '!' || r == '?' || r == '…' || r == '\n'
+	}) {
+		if words := len(strings.Fields(sentence)); words > 0 {
+			s.Sentences++
+			s.SentenceWords += words
+		}
+	}
+}
+
+// computeTypos finds rare words of every user that look like a misspelling of
+// a frequent word, see likelyTypo
+func (ys *YearStats) computeTypos() {
+	// Frequent words indexed by their spellings with one letter removed
+	deletions := make(map[string][]string)
+	for word, count := range ys.WordFrequency {
+		if count < minTypoBase || len([]rune(word)) < minTypoLength {
+			continue
+		}
+		deletions[word] = append(deletions[word], word)
+		for _, d := range deleteOne(word) {
+			deletions[d] = append(deletions[d], word)
+		}
+	}
+
+	ys.TyposByUser = make(map[string][]Typo)
+	for user, freq := range ys.WordFrequencyByUser {
+		var typos []Typo
+		for word, count := range freq {
+			if ys.WordFrequency[word] > maxTypoCount || len([]rune(word)) < minTypoLength {
+				continue
+			}
+			if correct := findCorrection(word, deletions, ys.WordFrequency); correct != "" {
+				typos = append(typos, Typo{Word: word, Correct: correct, Count: count})
+			}
+		}
+		sort.Slice(typos, func(i, j int) bool {
+			if typos[i].Count != typos[j].Count {
+				return typos[i].Count > typos[j].Count
+			}
+			return typos[i].Word < typos[j].Word
+		})
+		if len(typos) > userTypos {
+			typos = typos[:userTypos]
+		}
+		ys.TyposByUser[user] = typos
+	}
+}
+
+// deleteOne returns the word with each of its letters removed in turn
+func deleteOne(word string) []string {
+	runes := []rune(word)
+	result := make([]string, 0, len(runes))
+	for i := range runes {
+		result = append(result, string(runes[:i])+string(runes[i+1:]))
+	}
+	return result
+}
+
+// findCorrection returns the most frequent word a rare word may misspell
+func findCorrection(word string, deletions map[string][]string, freq map[string]int) string {
+	candidates := append([]string(nil), deletions[word]...)
+	for _, d := range deleteOne(word) {
+		candidates = append(candidates, deletions[d]...)
+	}
+	best := ""
+	for _, c := range candidates {
+		if c == word || !likelyTypo(word, c) {
+			continue
+		}
+		if best == "" || freq[c] > freq[best] || (freq[c] == freq[best] && c < best) {
+			best = c
+		}
+	}
+	return best
+}
+
+// keyboardRows are the letter rows of the Russian and English keyboard layouts
+var keyboardRows = [][]string{
+	{"йцукенгшщзхъ", "фывапролджэ", "ячсмитьбю"},
+	{"qwertyuiop", "asdfghjkl", "zxcvbnm"},
+}
+
+// keyPosition is the layout, row and column of a letter key
+type keyPosition struct{ layout, row, col int }
+
+var keyPositions = func() map[rune]keyPosition {
+	positions := make(map[rune]keyPosition)
+	for l, rows := range keyboardRows {
+		for r, row := range rows {
+			for c, key := range []rune(row) {
+				positions[key] = keyPosition{l, r, c}
+			}
+		}
+	}
+	return positions
+}()
+
+// neighbourKeys reports whether two letters are next to each other on the keyboard.
+// Rows are staggered, so a key touches the key above it and the one up and right.
+func neighbourKeys(a, b rune) bool {
+	pa, okA := keyPositions[a]
+	pb, okB := keyPositions[b]
+	if !okA || !okB || pa.layout != pb.layout {
+		return false
+	}
+	dc := pb.col - pa.col
+	switch pb.row - pa.row {
+	case 0:
+		return dc == 1 || dc == -1
+	case 1:
+		return dc == 0 || dc == -1
+	case -1:
+		return dc == 0 || dc == 1
+	}
+	return false
+}
+
+// confusedVowels reports whether two vowels are often mixed up when unstressed
+func confusedVowels(a, b rune) bool {
+	pair := string([]rune{a, b})
+	return pair == "еи" || pair == "ие" || pair == "ао" || pair == "оа" || pair == "ея" || pair == "яе"
+}
+
+// likelyTypo reports whether word is a misspelling of correct: one letter is
+// missed, doubled, typed with a neighbouring key or an unstressed vowel is
+// confused. Edits among the last two letters are not counted, since inflection
+// changes words there, and neither are other substitutions, which usually make
+// another real word ("тебя" and "себя").
+func likelyTypo(word, correct string) bool {
+	w, c := []rune(word), []rune(correct)
+	i := 0
+	for i < len(w) && i < len(c) && w[i] == c[i] {
+		i++
+	}
+	if i >= max(len(w), len(c))-2 {
+		return false
+	}
+	switch len(w) - len(c) {
+	case 0: // wrong letter
+		return string(w[i+1:]) == string(c[i+1:]) && (neighbourKeys(w[i], c[i]) || confusedVowels(w[i], c[i]))
+	case -1: // missed letter
+		return string(w[i:]) == string(c[i+1:])
+	case 1: // extra letter, doubled or typed with a key next to a neighbouring letter
+		if string(w[i+1:]) != string(c[i:]) {
+			return false
+		}
+		extra := w[i]
+		return (i > 0 && (extra == w[i-1] || neighbourKeys(extra, w[i-1]))) ||
+			extra == w[i+1] || neighbourKeys(extra, w[i+1])
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+package analyzer
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// topicVocabularySize limits the words used by the topic model
+	topicVocabularySize = 2000
+	// topicMinDocFreq drops words seen on fewer days
+	topicMinDocFreq = 3
+	// topicMaxDocShare drops words seen on more than this share of days
+	topicMaxDocShare = 0.5
+	// topicIterations is the number of NMF multiplicative updates
+	topicIterations = 150
+	// topicWords is how many key words describe a topic
+	topicWords = 8
+)
+
+// Topic is a group of words that tend to be used on the same days
+type Topic struct {
+	ID    int
+	Words []WordScore // key words with their weight in the topic
+	Share float64     // share of all words attributed to the topic
+}
+
+// topicCorpus collects day-level documents for the topic model
+type topicCorpus struct {
+	days map[string]map[string]int // "YYYY-MM-DD" -> word -> count
+}
+
+func newTopicCorpus() *topicCorpus {
+	return &topicCorpus{days: make(map[string]map[string]int)}
+}
+
+// add appends the filtered words of a message to its day document
+func (c *topicCorpus) add(day string, words []string) {
+	if len(words) == 0 {
+		return
+	}
+	if c.days[day] == nil {
+		c.days[day] = make(map[string]int)
+	}
+	for _, w := range words {
+		c.days[day][w]++
+	}
+}
+
+// computeTopics factorises the day-word TF-IDF matrix X ≈ W·H with non-negative
+// matrix factorisation (Lee & Seung multiplicative updates). Rows of H are
+// topics over words, rows of W are topic weights of each day.
+func computeTopics(stats *Stats, corpus *topicCorpus, k int) {
+	if k <= 0 {
+		return
+	}
+
+	dayKeys := make([]string, 0, len(corpus.days))
+	for day := range corpus.days {
+		dayKeys = append(dayKeys, day)
+	}
+	sort.Strings(dayKeys)
+	if len(dayKeys) < 2*k {
+		return
+	}
+
+	vocab := topicVocabulary(corpus, dayKeys)
+	if len(vocab) < k {
+		return
+	}
+	index := make(map[string]int, len(vocab))
+	for i, w := range vocab {
+		index[w] = i
+	}
+
+	// Sparse TF-IDF rows, L2-normalised so that busy days do not dominate
+	docFreq := make([]int, len(vocab))
+	for _, day := range dayKeys {
+		for w := range corpus.days[day] {
+			if i, ok := index[w]; ok {
+				docFreq[i]++
+			}
+		}
+	}
+	type entry struct {
+		col int
+		val float64
+	}
+	rows := make([][]entry, len(dayKeys))
+	dayWords := make([]float64, len(dayKeys))
+	for d, day := range dayKeys {
+		norm := 0.0
+		for w, count := range corpus.days[day] {
+			i, ok := index[w]
+			if !ok {
+				continue
+			}
+			v := float64(count) * math.Log(float64(len(dayKeys))/float64(docFreq[i]))
+			rows[d] = append(rows[d], entry{i, v})
+			norm += v * v
+			dayWords[d] += float64(count)
+		}
+		sort.Slice(rows[d], func(a, b int) bool { return rows[d][a].col < rows[d][b].col })
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for j := range rows[d] {
+				rows[d][j].val /= norm
+			}
+		}
+	}
+
+	// Deterministic random initialisation
+	rng := rand.New(rand.NewSource(1))
+	W := newMatrix(len(dayKeys), k)
+	H := newMatrix(k, len(vocab))
+	for i := range W {
+		for j := range W[i] {
+			W[i][j] = rng.Float64() + 0.01
+		}
+	}
+	for i := range H {
+		for j := range H[i] {
+			H[i][j] = rng.Float64() + 0.01
+		}
+	}
+
+	const eps = 1e-9
+	for iter := 0; iter < topicIterations; iter++ {
+		// H ← H ∘ (WᵀX) / (WᵀW·H)
+		WtX := newMatrix(k, len(vocab))
+		for d, row := range rows {
+			for _, e := range row {
+				for t := 0; t < k; t++ {
+					WtX[t][e.col] += W[d][t] * e.val
+				}
+			}
+		}
+		WtW := gram(W, k)
+		for t := 0; t < k; t++ {
+			for v := range vocab {
+				denom := 0.0
+				for s := 0; s < k; s++ {
+					denom += WtW[t][s] * H[s][v]
+				}
+				H[t][v] *= WtX[t][v] / (denom + eps)
+			}
+		}
+
+		// W ← W ∘ (XHᵀ) / (W·HHᵀ)
+		HHt := newMatrix(k, k)
+		for a := 0; a < k; a++ {
+			for b := 0; b < k; b++ {
+				for v := range vocab {
+					HHt[a][b] += H[a][v] * H[b][v]
+				}
+			}
+		}
+		for d, row := range rows {
+			for t := 0; t < k; t++ {
+				num := 0.0
+				for _, e := range row {
+					num += e.val * H[t][e.col]
+				}
+				denom := 0.0
+				for s := 0; s < k; s++ {
+					denom += W[d][s] * HHt[s][t]
+				}
+				W[d][t] *= num / (denom + eps)
+			}
+		}
+	}
+
+	// Topic weight of each day, scaled by how many words were written that day
+	stats.TopicsByMonth = make(map[string][]float64)
+	totals := make([]float64, k)
+	for d, day := range dayKeys {
+		sum := 0.0
+		for t := 0; t < k; t++ {
+			sum += W[d][t]
+		}
+		if sum == 0 {
+			continue
+		}
+		month := day[:7]
+		if stats.TopicsByMonth[month] == nil {
+			stats.TopicsByMonth[month] = make([]float64, k)
+		}
+		for t := 0; t < k; t++ {
+			share := W[d][t] / sum * dayWords[d]
+			stats.TopicsByMonth[month][t] += share
+			totals[t] += share
+		}
+	}
+	for _, shares := range stats.TopicsByMonth {
+		normalizeShares(shares)
+	}
+	normalizeShares(totals)
+
+	stats.Topics = make([]Topic, k)
+	for t := 0; t < k; t++ {
+		words := make([]WordScore, len(vocab))
+		for v, w := range vocab {
+			words[v] = WordScore{WordCount: WordCount{Word: w, Count: stats.Overall.WordFrequency[w]}, Score: H[t][v]}
+		}
+		sort.Slice(words, func(a, b int) bool {
+			if words[a].Score != words[b].Score {
+				return words[a].Score > words[b].Score
+			}
+			return words[a].Word < words[b].Word
+		})
+		if len(words) > topicWords {
+			words = words[:topicWords]
+		}
+		setSignatureForms(words, stats.Overall.WordForms)
+		stats.Topics[t] = Topic{ID: t + 1, Words: words, Share: totals[t]}
+	}
+}
+
+// topicVocabulary picks the most frequent words that are neither rare nor ubiquitous
+func topicVocabulary(corpus *topicCorpus, dayKeys []string) []string {
+	docFreq := make(map[string]int)
+	total := make(map[string]int)
+	for _, day := range dayKeys {
+		for w, c := range corpus.days[day] {
+			docFreq[w]++
+			total[w] += c
+		}
+	}
+
+	maxDocs := int(topicMaxDocShare * float64(len(dayKeys)))
+	vocab := make([]string, 0)
+	for w, df := range docFreq {
+		if df >= topicMinDocFreq && df <= maxDocs {
+			vocab = append(vocab, w)
+		}
+	}
+	sort.Slice(vocab, func(i, j int) bool {
+		if total[vocab[i]] != total[vocab[j]] {
+			return total[vocab[i]] > total[vocab[j]]
+		}
+		return vocab[i] < vocab[j]
+	})
+	if len(vocab) > topicVocabularySize {
+		vocab = vocab[:topicVocabularySize]
+	}
+	return vocab
+}
+
+// newMatrix allocates a rows×cols matrix of zeros
+func newMatrix(rows, cols int) [][]float64 {
+	m := make([][]float64, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+	}
+	return m
+}
+
+// gram returns MᵀM for a matrix with k columns
+func gram(m [][]float64, k int) [][]float64 {
+	g := newMatrix(k, k)
+	for _, row := range m {
+		for a := 0; a < k; a++ {
+			for b := 0; b < k; b++ {
+				g[a][b] += row[a] * row[b]
+			}
+		}
+	}
+	return g
+}
+
+// normalizeShares scales values so that they sum to 1
+func normalizeShares(values []float64) {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	if sum == 0 {
+		return
+	}
+	for i := range values {
+		values[i] /= sum
+	}
+}
@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// TrackedTerm is a named word list or regular expression counted over time
+type TrackedTerm struct {
+	Name    string
+	Pattern string
+	re      *regexp.Regexp
+}
+
+// TermStats contains how many messages matched a tracked term
+type TermStats struct {
+	Name        string
+	Pattern     string
+	Total       int
+	ByUser      map[string]int
+	ByMonth     map[string]int            // "YYYY-MM" -> messages
+	ByUserMonth map[string]map[string]int // user -> "YYYY-MM" -> messages
+}
+
+// ParseTrackedTerm parses a definition like "кино = кино, фильм, сериал" or
+// "смех = /а?(ха){2,}/". Plain words match the beginning of words, so "фильм"
+// also matches "фильмы"; patterns between slashes are regular expressions.
+// Matching is case-insensitive.
+func ParseTrackedTerm(def string) (TrackedTerm, error) {
+	name, expr, ok := strings.Cut(def, "=")
+	name, expr = strings.TrimSpace(name), strings.TrimSpace(expr)
+	if !ok || name == "" || expr == "" {
+		return TrackedTerm{}, fmt.Errorf("invalid term %q, expected \"name = words\" or \"name = /regexp/\"", def)
+	}
+
+	var pattern string
+	if len(expr) > 1 && strings.HasPrefix(expr, "/") && strings.HasSuffix(expr, "/") {
+		pattern = expr[1 : len(expr)-1]
+	} else {
+		words := make([]string, 0)
+		for _, w := range strings.Split(expr, ",") {
+			if w = strings.TrimSpace(w); w != "" {
+				words = append(words, regexp.QuoteMeta(w))
+			}
+		}
+		if len(words) == 0 {
+			return TrackedTerm{}, fmt.Errorf("term %q has no words", name)
+		}
+		pattern = `(?:^|[^\p{L}\p{N}])(?:` + strings.Join(words, "|") + `)`
+	}
+
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return TrackedTerm{}, fmt.Errorf("invalid pattern for term %q: %w", name, err)
+	}
+	return TrackedTerm{Name: name, Pattern: expr, re: re}, nil
+}
+
+// LoadTrackedTerms reads term definitions, one per line.
+// Empty lines and lines starting with "#" are skipped.
+func LoadTrackedTerms(path string) ([]TrackedTerm, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open terms file: %w", err)
+	}
+	defer f.Close()
+
+	var terms []TrackedTerm
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		term, err := ParseTrackedTerm(line)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read terms file %s: %w", path, err)
+	}
+	return terms, nil
+}
+
+// newTermStats creates empty counters for each tracked term
+func newTermStats(terms []TrackedTerm) []TermStats {
+	result := make([]TermStats, len(terms))
+	for i, term := range terms {
+		result[i] = TermStats{
+			Name:        term.Name,
+			Pattern:     term.Pattern,
+			ByUser:      make(map[string]int),
+			ByMonth:     make(map[string]int),
+			ByUserMonth: make(map[string]map[string]int),
+		}
+	}
+	return result
+}
+
+// countTrackedTerms records a message in the counters of every term it matches
+func countTrackedTerms(terms []TrackedTerm, counters []TermStats, user, month, text string) {
+	for i, term := range terms {
+		if !term.re.MatchString(text) {
+			continue
+		}
+		c := &counters[i]
+		c.Total++
+		c.ByUser[user]++
+		c.ByMonth[month]++
+		incNested(c.ByUserMonth, user, month)
+	}
+}
@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// mtldThreshold is the type-token ratio at which an MTLD segment is complete
+	mtldThreshold = 0.72
+	// minMTLDTokens is the minimal number of words for MTLD to be meaningful
+	minMTLDTokens = 50
+	// minNewWordCount drops one-off typos from the new words of a period
+	minNewWordCount = 3
+	// newWords is how many new words a period keeps
+	newWords = 20
+)
+
+// VocabularyStats describes how varied the words of a user or a period are
+type VocabularyStats struct {
+	Tokens int     // words written
+	Types  int     // distinct words
+	TTR    float64 // type-token ratio
+	MTLD   float64 // measure of textual lexical diversity, 0 for too short texts
+}
+
+// NewWord is a word first used in the chat during a period
+type NewWord struct {
+	WordCount           // Count is the number of uses in the period
+	User      string    // who used the word first
+	Date      time.Time // when it was first used
+}
+
+// firstUse records who used a word first and when
+type firstUse struct {
+	user string
+	date time.Time
+}
+
+// getVocabularyStats computes type-token ratio and MTLD of a word sequence
+func getVocabularyStats(tokens []string) VocabularyStats {
+	types := make(map[string]bool)
+	for _, t := range tokens {
+		types[t] = true
+	}
+	vs := VocabularyStats{Tokens: len(tokens), Types: len(types)}
+	if len(tokens) > 0 {
+		vs.TTR = float64(len(types)) / float64(len(tokens))
+	}
+	if len(tokens) >= minMTLDTokens {
+		reversed := make([]string, len(tokens))
+		for i, t := range tokens {
+			reversed[len(tokens)-1-i] = t
+		}
+		vs.MTLD = (mtldPass(tokens) + mtldPass(reversed)) / 2
+	}
+	return vs
+}
+
+// mtldPass counts how many segments keep the type-token ratio above the threshold
+// (McCarthy & Jarvis, 2010) and returns the mean segment length
+func mtldPass(tokens []string) float64 {
+	factors := 0.0
+	types := make(map[string]bool)
+	count := 0
+	ttr := 1.0
+	for _, t := range tokens {
+		count++
+		types[t] = true
+		ttr = float64(len(types)) / float64(count)
+		if ttr <= mtldThreshold {
+			factors++
+			types = make(map[string]bool)
+			count = 0
+			ttr = 1.0
+		}
+	}
+	if count > 0 {
+		factors += (1 - ttr) / (1 - mtldThreshold)
+	}
+	if factors == 0 {
+		return float64(len(tokens))
+	}
+	return float64(len(tokens)) / factors
+}
+
+// finalizeVocabulary computes vocabulary statistics and drops the word sequences
+func (ys *YearStats) finalizeVocabulary() {
+	ys.Vocabulary = getVocabularyStats(ys.tokens)
+	ys.VocabularyByUser = make(map[string]VocabularyStats)
+	for user, tokens := range ys.tokensByUser {
+		ys.VocabularyByUser[user] = getVocabularyStats(tokens)
+	}
+	ys.tokens = nil
+	ys.tokensByUser = nil
+}
+
+// computeNewWords fills the words first used in each year and report period.
+// The first period of the chat is skipped since every word is new there.
+func computeNewWords(stats *Stats, firstUses map[string]firstUse) {
+	periods := make([]*YearStats, 0, len(stats.ByYear)+len(stats.ByPeriod))
+	for _, ys := range stats.ByYear {
+		periods = append(periods, ys)
+	}
+	if !stats.calendarYears() {
+		for _, ps := range stats.ByPeriod {
+			periods = append(periods, ps)
+		}
+	}
+
+	chatStart := stats.Overall.FirstMessage
+	for _, ps := range periods {
+		if !ps.Period.Start.After(chatStart) {
+			continue
+		}
+		words := make([]NewWord, 0)
+		for word, count := range ps.WordFrequency {
+			first, ok := firstUses[word]
+			if !ok || count < minNewWordCount || first.date.Before(ps.Period.Start) {
+				continue
+			}
+			words = append(words, NewWord{
+				WordCount: WordCount{Word: word, Form: mostCommonKey(ps.WordForms[word]), Count: count},
+				User:      first.user,
+				Date:      first.date,
+			})
+		}
+		sort.Slice(words, func(i, j int) bool {
+			if words[i].Count != words[j].Count {
+				return words[i].Count > words[j].Count
+			}
+			return words[i].Word < words[j].Word
+		})
+		if len(words) > newWords {
+			words = words[:newWords]
+		}
+		ps.NewWords = words
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"telegram_message_analyzer/analyzer"
+	"telegram_message_analyzer/attribution"
+	"telegram_message_analyzer/parser"
+)
+
+// runAttribute implements the "attribute" command: it trains a classifier on
+// messages with a known sender and scores the text from the command line or,
+// without one, every message whose sender is guessed or deleted
+func runAttribute(args []string) {
+	fs := flag.NewFlagSet("attribute", flag.ExitOnError)
+	dataDir := fs.String("data", "path_to_tg", "Directory with exported Telegram HTML files")
+	top := fs.Int("top", 3, "Number of most likely authors to show")
+	limit := fs.Int("limit", 50, "Maximum number of unlabeled messages to print (0 prints all)")
+	fromDate := fs.String("from", "", "Only messages sent on or after this date (YYYY-MM-DD)")
+	toDate := fs.String("to", "", "Only messages sent on or before this date (YYYY-MM-DD)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Использование: attribute [-data DIR] [-top N] [-limit N] [-from ДАТА] [-to ДАТА] [текст]")
+		fmt.Fprintln(os.Stderr, "Без текста оцениваются сообщения с угаданным отправителем и от удаленных аккаунтов")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	absDataDir, err := filepath.Abs(*dataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: не удалось получить путь к данным: %v\n", err)
+		os.Exit(1)
+	}
+
+	filter, err := buildFilter(*fromDate, *toDate, "", "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		os.Exit(1)
+	}
+
+	result, err := parser.ParseAllFiles(absDataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка парсинга: %v\n", err)
+		os.Exit(1)
+	}
+	if !filter.IsEmpty() {
+		result = filter.Apply(result)
+	}
+
+	model := attribution.Train(result.Messages)
+	if len(model.Authors()) < 2 {
+		fmt.Fprintf(os.Stderr, "Ошибка: нужно хотя бы два участника с %d и более сообщениями\n", attribution.MinMessages)
+		os.Exit(1)
+	}
+	fmt.Println("Модель обучена на сообщениях участников:")
+	for _, author := range model.Authors() {
+		fmt.Printf("  %s — %d\n", author, model.Messages(author))
+	}
+	if correct, total := attribution.Evaluate(result.Messages, 5); total > 0 {
+		fmt.Printf("Точность на отложенных сообщениях: %.0f%% (%d из %d)\n",
+			float64(correct)/float64(total)*100, correct, total)
+	}
+
+	if fs.NArg() > 0 {
+		text := strings.Join(fs.Args(), " ")
+		fmt.Printf("\nТекст: %s\n", text)
+		printScores(model.Score(text), *top)
+		return
+	}
+
+	var unlabeled []parser.Message
+	for _, msg := range result.Messages {
+		if msg.IsUnlabeled() && !msg.IsForwarded {
+			unlabeled = append(unlabeled, msg)
+		}
+	}
+	fmt.Printf("\nСообщений без достоверного отправителя: %d\n", len(unlabeled))
+	if len(unlabeled) == 0 {
+		return
+	}
+
+	likely := make(map[string]int)
+	for i, msg := range unlabeled {
+		scores := model.Score(msg.Text)
+		likely[scores[0].Author]++
+		if *limit > 0 && i >= *limit {
+			continue
+		}
+		reason := "удаленный аккаунт"
+		if msg.Inferred {
+			reason = "отправитель угадан"
+		}
+		fmt.Println("\n──────────")
+		fmt.Printf("[%s] %s (%s): %s\n", msg.Date.Format("02.01.2006 15:04"), msg.From, reason, msg.Text)
+		printScores(scores, *top)
+	}
+	if *limit > 0 && len(unlabeled) > *limit {
+		fmt.Printf("\nПоказаны первые %d\n", *limit)
+	}
+
+	fmt.Println("\nВероятные авторы:")
+	for _, u := range analyzer.GetSortedUsers(likely) {
+		fmt.Printf("  %s — %d\n", u.Name, u.Count)
+	}
+}
+
+// printScores prints the most likely authors of a text
+func printScores(scores []attribution.Score, top int) {
+	for i, s := range scores {
+		if i >= top {
+			break
+		}
+		fmt.Printf("  %d. %s — %.1f%%\n", i+1, s.Author, s.Probability*100)
+	}
+}
@@ -0,0 +1,167 @@
+package attribution
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"telegram_message_analyzer/parser"
+)
+
+const (
+	// minGram and maxGram are the lengths of character n-grams the model uses
+	minGram = 2
+	maxGram = 4
+	// MinMessages is how many labelled messages an author needs to be modelled
+	MinMessages = 20
+)
+
+// Model is a naive Bayes classifier over the character n-grams of every author
+type Model struct {
+	authors    []string
+	messages   map[string]int            // author -> training messages
+	grams      map[string]map[string]int // author -> n-gram -> count
+	totals     map[string]int            // author -> number of n-grams
+	vocabulary map[string]bool
+	trained    int
+}
+
+// Score is the probability that a text was written by an author
+type Score struct {
+	Author      string
+	Probability float64
+}
+
+// Train builds a model from messages with a known sender. Unlabeled and
+// forwarded messages are skipped, as are authors with too few messages.
+func Train(messages []parser.Message) *Model {
+	var labelled []parser.Message
+	counts := make(map[string]int)
+	for _, msg := range messages {
+		if msg.IsUnlabeled() || msg.IsForwarded || msg.From == "" {
+			continue
+		}
+		labelled = append(labelled, msg)
+		counts[msg.From]++
+	}
+
+	m := &Model{
+		messages:   make(map[string]int),
+		grams:      make(map[string]map[string]int),
+		totals:     make(map[string]int),
+		vocabulary: make(map[string]bool),
+	}
+	for _, msg := range labelled {
+		if counts[msg.From] < MinMessages {
+			continue
+		}
+		author := msg.From
+		if m.grams[author] == nil {
+			m.grams[author] = make(map[string]int)
+			m.authors = append(m.authors, author)
+		}
+		m.messages[author]++
+		m.trained++
+		for _, g := range ngrams(msg.Text) {
+			m.grams[author][g]++
+			m.totals[author]++
+			m.vocabulary[g] = true
+		}
+	}
+	sort.Strings(m.authors)
+	return m
+}
+
+// Authors returns the modelled authors in alphabetical order
+func (m *Model) Authors() []string {
+	return m.authors
+}
+
+// Messages returns how many messages of an author the model was trained on
+func (m *Model) Messages(author string) int {
+	return m.messages[author]
+}
+
+// Score returns the probability of every modelled author having written the
+// text, most likely first
+func (m *Model) Score(text string) []Score {
+	if len(m.authors) == 0 {
+		return nil
+	}
+	grams := ngrams(text)
+	vocab := float64(len(m.vocabulary) + 1)
+
+	logs := make([]float64, len(m.authors))
+	best := math.Inf(-1)
+	for i, author := range m.authors {
+		// Prior from the share of messages, likelihood with add-one smoothing
+		lp := math.Log(float64(m.messages[author]) / float64(m.trained))
+		denom := math.Log(float64(m.totals[author]) + vocab)
+		for _, g := range grams {
+			lp += math.Log(float64(m.grams[author][g]+1)) - denom
+		}
+		logs[i] = lp
+		best = max(best, lp)
+	}
+
+	// Normalize log-likelihoods to probabilities without underflow
+	sum := 0.0
+	for i := range logs {
+		logs[i] = math.Exp(logs[i] - best)
+		sum += logs[i]
+	}
+	scores := make([]Score, len(m.authors))
+	for i, author := range m.authors {
+		scores[i] = Score{Author: author, Probability: logs[i] / sum}
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Probability > scores[j].Probability
+	})
+	return scores
+}
+
+// Evaluate trains on the labelled messages except every n-th one and reports
+// how many of the held out messages of modelled authors are attributed correctly
+func Evaluate(messages []parser.Message, every int) (correct, total int) {
+	var train, test []parser.Message
+	n := 0
+	for _, msg := range messages {
+		if msg.IsUnlabeled() || msg.IsForwarded || msg.From == "" {
+			continue
+		}
+		if n%every == every-1 {
+			test = append(test, msg)
+		} else {
+			train = append(train, msg)
+		}
+		n++
+	}
+
+	m := Train(train)
+	for _, msg := range test {
+		if m.messages[msg.From] == 0 {
+			continue
+		}
+		total++
+		if scores := m.Score(msg.Text); scores[0].Author == msg.From {
+			correct++
+		}
+	}
+	return correct, total
+}
+
+// ngrams returns the character n-grams of a lowercased text with collapsed
+// whitespace, padded so that word starts and ends form n-grams of their own
+func ngrams(text string) []string {
+	runes := []rune(" " + strings.Join(strings.Fields(strings.ToLower(text)), " ") + " ")
+	if len(runes) <= 2 {
+		return nil
+	}
+	var result []string
+	for n := minGram; n <= maxGram; n++ {
+		for i := 0; i+n <= len(runes); i++ {
+			result = append(result, string(runes[i:i+n]))
+		}
+	}
+	return result
+}
@@ -5,22 +5,58 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"telegram_message_analyzer/analyzer"
 	"telegram_message_analyzer/output"
 	"telegram_message_analyzer/parser"
+	"telegram_message_analyzer/stopwords"
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "search" {
+		runSearch(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "attribute" {
+		runAttribute(os.Args[2:])
+		return
+	}
+
 	// Parse command line arguments
-	dataDir := flag.String("data", "path_to_tg", "Directory with exported Telegram HTML files")
+	dataDir := flag.String("data", "path_to_tg", "Directory with exported Telegram HTML files (comma-separated to compare several chats)")
 	outputDir := flag.String("output", "path_to_reports", "Directory for output markdown reports")
+	normalize := flag.String("normalize", "none", "Word normalization: none or stem (Russian Snowball stemmer)")
+	stopWordFiles := flag.String("stopwords", "", "Comma-separated files with extra stop words or per-chat ignore lists")
+	keepWordFiles := flag.String("keep", "", "Comma-separated files with built-in stop words that should still be counted")
+	trackFile := flag.String("track", "", "File with terms to track over time, one \"name = words\" or \"name = /regexp/\" per line")
+	topics := flag.Int("topics", 8, "Number of topics to extract (0 disables topic modelling)")
+	period := flag.String("period", "year", "Report period: year, quarter, month or week")
+	yearStart := flag.Int("year-start", 1, "First month of years and quarters (9 for academic years, fiscal year start month)")
+	compare := flag.String("compare", "", "Compare two periods: \"last\", two period keys like \"2022,2023\" or ranges like \"2023-01-01..2023-03-31,2023-04-01..2023-06-30\"")
+	fromDate := flag.String("from", "", "Only messages sent on or after this date (YYYY-MM-DD)")
+	toDate := flag.String("to", "", "Only messages sent on or before this date (YYYY-MM-DD)")
+	users := flag.String("users", "", "Comma-separated senders to include (default: everyone)")
+	excludeUsers := flag.String("exclude-users", "", "Comma-separated senders to exclude")
+	excludeDuplicates := flag.Bool("exclude-duplicates", false, "Leave repeated and near-duplicate messages (copypasta) out of word counts")
+	silence := flag.Duration("silence", analyzer.DefaultInitiatorSilence, "Silence after which the next message starts a new conversation, e.g. 3h or 90m")
+	maskProfanity := flag.Bool("mask-profanity", false, "Hide obscene words in all generated reports (\"х**\")")
 	flag.Parse()
 
 	// Get absolute paths
-	absDataDir, err := filepath.Abs(*dataDir)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Ошибка: не удалось получить путь к данным: %v\n", err)
+	var absDataDirs []string
+	for _, dir := range splitList(*dataDir) {
+		absDataDir, err := filepath.Abs(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: не удалось получить путь к данным: %v\n", err)
+			os.Exit(1)
+		}
+		absDataDirs = append(absDataDirs, absDataDir)
+	}
+	if len(absDataDirs) == 0 {
+		fmt.Fprintln(os.Stderr, "Ошибка: не указана директория с данными")
 		os.Exit(1)
 	}
 
@@ -30,12 +66,99 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Build analysis options
+	opts := analyzer.DefaultOptions()
+	mode, ok := analyzer.ParseNormalization(*normalize)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Ошибка: неизвестный режим нормализации: %s\n", *normalize)
+		os.Exit(1)
+	}
+	opts.Normalization = mode
+	opts.Topics = *topics
+	opts.ExcludeDuplicates = *excludeDuplicates
+	if *silence <= 0 {
+		fmt.Fprintf(os.Stderr, "Ошибка: пауза между разговорами должна быть положительной: %s\n", *silence)
+		os.Exit(1)
+	}
+	opts.InitiatorSilence = *silence
+
+	if opts.Period, ok = analyzer.ParsePeriodKind(*period); !ok {
+		fmt.Fprintf(os.Stderr, "Ошибка: неизвестный период: %s\n", *period)
+		os.Exit(1)
+	}
+	if *yearStart < 1 || *yearStart > 12 {
+		fmt.Fprintf(os.Stderr, "Ошибка: месяц начала года должен быть от 1 до 12: %d\n", *yearStart)
+		os.Exit(1)
+	}
+	opts.YearStart = time.Month(*yearStart)
+
+	if opts.StopWords, err = stopwords.LoadFiles(splitList(*stopWordFiles)); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: не удалось прочитать стоп-слова: %v\n", err)
+		os.Exit(1)
+	}
+	if opts.KeepWords, err = stopwords.LoadFiles(splitList(*keepWordFiles)); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: не удалось прочитать список исключений: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *trackFile != "" {
+		if opts.Track, err = analyzer.LoadTrackedTerms(*trackFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: не удалось прочитать отслеживаемые слова: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	filter, err := buildFilter(*fromDate, *toDate, *users, *excludeUsers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		os.Exit(1)
+	}
+
 	fmt.Println("╔══════════════════════════════════════════════════════════╗")
 	fmt.Println("║         АНАЛИЗАТОР TELEGRAM ЧАТОВ                        ║")
 	fmt.Println("╚══════════════════════════════════════════════════════════╝")
-	fmt.Printf("\nИсходные данные: %s\n", absDataDir)
+	fmt.Printf("\nИсходные данные: %s\n", strings.Join(absDataDirs, ", "))
 	fmt.Printf("Папка отчетов: %s\n\n", absOutputDir)
 
+	pdfDir := filepath.Join(absOutputDir, "pdf-report")
+	if len(absDataDirs) == 1 {
+		if analyzeChat(absDataDirs[0], absOutputDir, opts, filter, *compare, *maskProfanity) == nil {
+			os.Exit(0)
+		}
+	} else {
+		// Several exports: every chat gets its own reports, then they are compared
+		var chats []*analyzer.Stats
+		for i, dir := range absDataDirs {
+			fmt.Printf("\n━━━ Чат %d из %d: %s ━━━\n\n", i+1, len(absDataDirs), dir)
+			chatDir := filepath.Join(absOutputDir, fmt.Sprintf("%d_%s", i+1, filepath.Base(dir)))
+			if stats := analyzeChat(dir, chatDir, opts, filter, *compare, *maskProfanity); stats != nil {
+				chats = append(chats, stats)
+			}
+		}
+
+		fmt.Println("\n🔀 Сравнение чатов...")
+		if len(chats) < 2 {
+			fmt.Println("Предупреждение: для сравнения нужно хотя бы два чата с сообщениями")
+		} else {
+			cmp := analyzer.CompareChats(chats)
+			if err := output.GenerateChatComparisonReport(cmp, absOutputDir, *maskProfanity); err != nil {
+				fmt.Fprintf(os.Stderr, "Ошибка генерации отчета сравнения чатов: %v\n", err)
+				os.Exit(1)
+			}
+			if err := output.GenerateChatComparisonPDF(cmp, pdfDir, *maskProfanity); err != nil {
+				fmt.Fprintf(os.Stderr, "Предупреждение: не удалось создать PDF сравнения чатов: %v\n", err)
+			}
+		}
+	}
+
+	fmt.Println("\n✅ Анализ завершен!")
+	fmt.Printf("📁 MD отчеты: %s\n", absOutputDir)
+	fmt.Printf("📁 PDF отчеты: %s\n", pdfDir)
+}
+
+// analyzeChat parses one export, analyses it and writes its reports.
+// It returns nil if the export has no messages left after filtering.
+func analyzeChat(absDataDir, absOutputDir string, opts analyzer.Options, filter parser.Filter, compare string, mask bool) *analyzer.Stats {
 	// Check if data directory exists
 	if _, err := os.Stat(absDataDir); os.IsNotExist(err) {
 		fmt.Fprintf(os.Stderr, "Ошибка: директория с данными не найдена: %s\n", absDataDir)
@@ -50,21 +173,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	if !filter.IsEmpty() {
+		result = filter.Apply(result)
+		fmt.Printf("После фильтрации осталось: %d сообщений\n", len(result.Messages))
+	}
+
 	if len(result.Messages) == 0 {
 		fmt.Println("Предупреждение: не найдено текстовых сообщений")
-		os.Exit(0)
+		return nil
 	}
 
 	// Step 2: Analyze data
 	fmt.Println("\n📊 Анализ данных...")
-	stats := analyzer.Analyze(result)
+	stats := analyzer.Analyze(result, opts)
 
 	// Step 3: Print console statistics
-	output.PrintConsoleStats(stats)
+	output.PrintConsoleStats(stats, mask)
 
 	// Step 4: Generate markdown reports
 	fmt.Println("\n📝 Генерация MD отчетов...")
-	if err := output.GenerateReports(stats, absOutputDir); err != nil {
+	if err := output.GenerateReports(stats, absOutputDir, mask); err != nil {
 		fmt.Fprintf(os.Stderr, "Ошибка генерации MD отчетов: %v\n", err)
 		os.Exit(1)
 	}
@@ -72,12 +200,101 @@ func main() {
 	// Step 5: Generate PDF reports
 	pdfDir := filepath.Join(absOutputDir, "pdf-report")
 	fmt.Println("\n📄 Генерация PDF отчетов...")
-	if err := output.GeneratePDFReports(stats, pdfDir); err != nil {
+	if err := output.GeneratePDFReports(stats, pdfDir, mask); err != nil {
 		fmt.Fprintf(os.Stderr, "Предупреждение: не удалось создать PDF отчеты: %v\n", err)
 		// Don't exit - PDF is optional
 	}
 
-	fmt.Println("\n✅ Анализ завершен!")
-	fmt.Printf("📁 MD отчеты: %s\n", absOutputDir)
-	fmt.Printf("📁 PDF отчеты: %s\n", pdfDir)
+	// Step 6: Compare periods
+	if compare != "" {
+		fmt.Println("\n⚖️  Сравнение периодов...")
+		cmp, err := buildComparison(compare, stats, result, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка сравнения: %v\n", err)
+			os.Exit(1)
+		}
+		if err := output.GenerateComparisonReport(cmp, stats.ChatName, absOutputDir, mask); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка генерации отчета сравнения: %v\n", err)
+			os.Exit(1)
+		}
+		if err := output.GenerateComparisonPDF(cmp, stats.ChatName, pdfDir, mask); err != nil {
+			fmt.Fprintf(os.Stderr, "Предупреждение: не удалось создать PDF сравнения: %v\n", err)
+		}
+	}
+
+	return stats
+}
+
+// splitList splits a comma-separated flag value, skipping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// buildFilter creates a message filter from the date and participant flags
+func buildFilter(from, to, users, excludeUsers string) (parser.Filter, error) {
+	filter := parser.Filter{
+		Users:        splitList(users),
+		ExcludeUsers: splitList(excludeUsers),
+	}
+	var err error
+	if from != "" {
+		if filter.From, err = time.Parse("2006-01-02", from); err != nil {
+			return filter, fmt.Errorf("неверная дата -from %q, ожидается ГГГГ-ММ-ДД", from)
+		}
+	}
+	if to != "" {
+		if filter.To, err = time.Parse("2006-01-02", to); err != nil {
+			return filter, fmt.Errorf("неверная дата -to %q, ожидается ГГГГ-ММ-ДД", to)
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return filter, fmt.Errorf("дата -to раньше даты -from")
+	}
+	return filter, nil
+}
+
+// buildComparison resolves the -compare flag: "last" compares the two latest report
+// periods, otherwise two comma-separated period keys or date ranges are expected
+func buildComparison(spec string, stats *analyzer.Stats, result *parser.ParseResult, opts analyzer.Options) (*analyzer.Comparison, error) {
+	var parts []string
+	if spec == "last" {
+		periods := stats.GetSortedPeriods()
+		if len(periods) < 2 {
+			return nil, fmt.Errorf("для сравнения нужно хотя бы два периода")
+		}
+		parts = periods[len(periods)-2:]
+	} else {
+		parts = splitList(spec)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("ожидается два периода через запятую: %q", spec)
+		}
+	}
+
+	periods := make([]*analyzer.YearStats, 2)
+	for i, part := range parts {
+		if from, to, ok := strings.Cut(part, ".."); ok {
+			filter, err := buildFilter(from, to, "", "")
+			if err != nil {
+				return nil, err
+			}
+			if filter.From.IsZero() || filter.To.IsZero() {
+				return nil, fmt.Errorf("в диапазоне %q нужны обе даты", part)
+			}
+			periods[i] = analyzer.AnalyzeRange(result, opts, filter.From, filter.To)
+		} else if ps, ok := stats.ByPeriod[part]; ok {
+			periods[i] = ps
+		} else {
+			return nil, fmt.Errorf("период %q не найден", part)
+		}
+		if periods[i].TotalMessages == 0 {
+			return nil, fmt.Errorf("в периоде %q нет сообщений", part)
+		}
+	}
+	return analyzer.Compare(periods[0], periods[1]), nil
 }
@@ -0,0 +1,285 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"telegram_message_analyzer/analyzer"
+)
+
+// chatLabels returns column names for compared chats, numbering repeated names
+func chatLabels(cmp *analyzer.ChatComparison) []string {
+	labels := make([]string, len(cmp.Chats))
+	seen := make(map[string]int)
+	for i, chat := range cmp.Chats {
+		name := chat.ChatName
+		if name == "" {
+			name = fmt.Sprintf("Чат %d", i+1)
+		}
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s (%d)", name, seen[name])
+		}
+		labels[i] = name
+	}
+	return labels
+}
+
+// chatMetricRows lists the volume figures of every chat as table rows
+func chatMetricRows(cmp *analyzer.ChatComparison) [][]string {
+	rows := [][]string{
+		{"Период"}, {"Сообщений"}, {"Участников"}, {"Сообщений в день"}, {"Ответов"}, {"Пересланных"},
+		{"Средняя длина сообщения"}, {"Эмодзи на сообщение"}, {"Среднее настроение"}, {"Размер словаря"},
+		{"Самый активный период"},
+	}
+	for i, chat := range cmp.Chats {
+		s := &chat.Overall
+		values := []string{
+			fmt.Sprintf("%s — %s", s.FirstMessage.Format("02.01.2006"), s.LastMessage.Format("02.01.2006")),
+			fmt.Sprintf("%d", s.TotalMessages),
+			fmt.Sprintf("%d", len(s.MessagesByUser)),
+			fmt.Sprintf("%.1f", perDay(s)),
+			fmt.Sprintf("%.1f%%", perMessage(s.RepliesCount, s.TotalMessages)*100),
+			fmt.Sprintf("%.1f%%", perMessage(s.ForwardedCount, s.TotalMessages)*100),
+			fmt.Sprintf("%.1f", s.AvgMessageLength),
+			fmt.Sprintf("%.2f", perMessage(s.EmojiCount, s.TotalMessages)),
+			fmt.Sprintf("%+.3f", s.Sentiment.Average()),
+			fmt.Sprintf("%d", cmp.VocabularySizes[i]),
+			fmt.Sprintf("%02d:00-%02d:00", s.MostActiveWindow.StartHour, s.MostActiveWindow.EndHour),
+		}
+		for r, v := range values {
+			rows[r] = append(rows[r], v)
+		}
+	}
+	return rows
+}
+
+// chatUserCell formats a person's activity in one chat
+func chatUserCell(u analyzer.ChatUserShare, chat int) string {
+	if u.Messages[chat] == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.1f%% (%d)", u.Shares[chat]*100, u.Messages[chat])
+}
+
+// writeTableHeader writes a markdown table header with a separator line
+func writeTableHeader(sb *strings.Builder, first string, columns []string) {
+	sb.WriteString(fmt.Sprintf("| %s |", first))
+	for _, col := range columns {
+		sb.WriteString(fmt.Sprintf(" %s |", col))
+	}
+	sb.WriteString("\n|---|")
+	for range columns {
+		sb.WriteString("---|")
+	}
+	sb.WriteString("\n")
+}
+
+// GenerateChatComparisonReport writes a markdown report comparing several chats;
+// mask hides obscene words in word lists and message excerpts
+func GenerateChatComparisonReport(cmp *analyzer.ChatComparison, outputDir string, mask bool) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := filepath.Join(outputDir, "chats_comparison_report.md")
+	if err := os.WriteFile(filename, []byte(generateChatComparisonReport(cmp, mask)), 0644); err != nil {
+		return fmt.Errorf("failed to write chat comparison report: %w", err)
+	}
+	fmt.Printf("Создан отчет сравнения чатов: %s\n", filename)
+	return nil
+}
+
+// generateChatComparisonReport creates markdown content with one column per chat
+func generateChatComparisonReport(cmp *analyzer.ChatComparison, mask bool) string {
+	var sb strings.Builder
+	labels := chatLabels(cmp)
+
+	sb.WriteString("# Сравнение чатов\n\n")
+
+	// Volume
+	sb.WriteString("## Объем переписки\n\n")
+	writeTableHeader(&sb, "Показатель", labels)
+	for _, row := range chatMetricRows(cmp) {
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	sb.WriteString("\n")
+
+	// Active hours
+	sb.WriteString("## Активные часы\n\n")
+	writeTableHeader(&sb, "Часы", labels)
+	windows := make([][12]float64, len(cmp.Chats))
+	for i := range cmp.Chats {
+		windows[i] = hourWindowShares(cmp.HourShares[i])
+	}
+	for w := 0; w < 12; w++ {
+		sb.WriteString(fmt.Sprintf("| %02d:00-%02d:00 |", w*2, w*2+2))
+		for i := range cmp.Chats {
+			sb.WriteString(fmt.Sprintf(" %.1f%% |", windows[i][w]*100))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	// People
+	sb.WriteString("## Участники\n\n")
+	sb.WriteString("Доля сообщений участника в каждом чате (в скобках — число сообщений).\n\n")
+	writeTableHeader(&sb, "Участник", labels)
+	for n, u := range cmp.Users {
+		if n == 20 {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| %s |", u.Name))
+		for i := range cmp.Chats {
+			sb.WriteString(fmt.Sprintf(" %s |", chatUserCell(u, i)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	// Vocabulary
+	sb.WriteString("## Пересечение словарей\n\n")
+	sb.WriteString("Коэффициент Жаккара: доля общих слов среди всех слов, которые хотя бы в одном из двух чатов употреблялись 3 и более раз.\n\n")
+	writeTableHeader(&sb, "", labels)
+	for i, label := range labels {
+		sb.WriteString(fmt.Sprintf("| %s |", label))
+		for j := range labels {
+			sb.WriteString(fmt.Sprintf(" %.1f%% |", cmp.Overlap[i][j]*100))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	if len(cmp.SharedWords) > 0 {
+		sb.WriteString("### Общие слова всех чатов\n\n")
+		sb.WriteString(joinWordCounts(cmp.SharedWords, len(cmp.SharedWords), mask) + "\n\n")
+	}
+
+	sb.WriteString("### Слова, которые встречаются только в одном чате\n\n")
+	sb.WriteString("| Чат | Слова |\n")
+	sb.WriteString("|-----|-------|\n")
+	for i, label := range labels {
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", label, joinWordCounts(cmp.DistinctWords[i], 10, mask)))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// GenerateChatComparisonPDF writes a PDF report comparing several chats;
+// mask hides obscene words in word lists and message excerpts
+func GenerateChatComparisonPDF(cmp *analyzer.ChatComparison, outputDir string, mask bool) error {
+	fontPath := findFont()
+	if fontPath == "" {
+		return fmt.Errorf("не найден TTF шрифт с поддержкой кириллицы")
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := filepath.Join(outputDir, "chats_comparison_report.pdf")
+	gen := &PDFGenerator{fontPath: fontPath, mask: mask}
+	if err := gen.generateChatComparisonPDF(cmp, filename); err != nil {
+		return fmt.Errorf("failed to generate chat comparison PDF: %w", err)
+	}
+	fmt.Printf("Создан PDF отчет сравнения чатов: %s\n", filename)
+	return nil
+}
+
+func (g *PDFGenerator) generateChatComparisonPDF(cmp *analyzer.ChatComparison, filename string) error {
+	if err := g.initPDF(); err != nil {
+		return err
+	}
+	labels := chatLabels(cmp)
+
+	// Table columns share the page width after the first column
+	first := 150.0
+	width := (pageWidth - marginLeft - marginRight - first) / float64(len(labels))
+	widths := []float64{first}
+	for range labels {
+		widths = append(widths, width)
+	}
+	row := func(name string, cells []string) {
+		g.writeTableRow(append([]string{name}, cells...), widths)
+	}
+
+	g.writeTitle("Сравнение чатов")
+	g.addSpace(10)
+
+	g.writeHeader("Чаты")
+	for i, label := range labels {
+		g.writeLine(fmt.Sprintf("%d. %s", i+1, label))
+	}
+	g.addSpace(10)
+
+	numbers := make([]string, len(labels))
+	for i := range labels {
+		numbers[i] = fmt.Sprintf("Чат %d", i+1)
+	}
+
+	// Volume
+	g.writeHeader("Объем переписки")
+	row("Показатель", numbers)
+	for _, r := range chatMetricRows(cmp) {
+		if r[0] == "Период" {
+			continue
+		}
+		row(r[0], r[1:])
+	}
+	g.addSpace(10)
+
+	// Active hours
+	g.writeHeader("Активные часы")
+	row("Часы", numbers)
+	windows := make([][12]float64, len(cmp.Chats))
+	for i := range cmp.Chats {
+		windows[i] = hourWindowShares(cmp.HourShares[i])
+	}
+	for w := 0; w < 12; w++ {
+		cells := make([]string, len(cmp.Chats))
+		for i := range cmp.Chats {
+			cells[i] = fmt.Sprintf("%.1f%%", windows[i][w]*100)
+		}
+		row(fmt.Sprintf("%02d:00-%02d:00", w*2, w*2+2), cells)
+	}
+	g.addSpace(10)
+
+	// People
+	g.writeHeader("Участники")
+	row("Участник", numbers)
+	for n, u := range cmp.Users {
+		if n == 20 {
+			break
+		}
+		cells := make([]string, len(cmp.Chats))
+		for i := range cmp.Chats {
+			cells[i] = chatUserCell(u, i)
+		}
+		row(u.Name, cells)
+	}
+	g.addSpace(10)
+
+	// Vocabulary
+	g.writeHeader("Пересечение словарей")
+	row("", numbers)
+	for i := range labels {
+		cells := make([]string, len(labels))
+		for j := range labels {
+			cells[j] = fmt.Sprintf("%.1f%%", cmp.Overlap[i][j]*100)
+		}
+		row(numbers[i], cells)
+	}
+	g.addSpace(5)
+	if len(cmp.SharedWords) > 0 {
+		g.writeSubHeader("Общие слова всех чатов")
+		g.writeLine(joinWordCounts(cmp.SharedWords, 10, g.mask))
+	}
+	g.writeSubHeader("Слова, которые встречаются только в одном чате")
+	for i := range labels {
+		g.writeLine(fmt.Sprintf("%s: %s", numbers[i], joinWordCounts(cmp.DistinctWords[i], 6, g.mask)))
+	}
+
+	return g.pdf.WritePdf(filename)
+}
@@ -0,0 +1,293 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"telegram_message_analyzer/analyzer"
+)
+
+// comparisonMetric is a number shown for both compared periods
+type comparisonMetric struct {
+	name          string
+	before, after float64
+	format        string
+}
+
+// comparisonMetrics lists the volume figures of a comparison
+func comparisonMetrics(cmp *analyzer.Comparison) []comparisonMetric {
+	b, a := cmp.Before, cmp.After
+	return []comparisonMetric{
+		{"Сообщений", float64(b.TotalMessages), float64(a.TotalMessages), "%.0f"},
+		{"Участников", float64(len(b.MessagesByUser)), float64(len(a.MessagesByUser)), "%.0f"},
+		{"Сообщений в день", perDay(b), perDay(a), "%.1f"},
+		{"Ответов", float64(b.RepliesCount), float64(a.RepliesCount), "%.0f"},
+		{"Пересланных", float64(b.ForwardedCount), float64(a.ForwardedCount), "%.0f"},
+		{"Средняя длина сообщения", b.AvgMessageLength, a.AvgMessageLength, "%.1f"},
+		{"Эмодзи на сообщение", perMessage(b.EmojiCount, b.TotalMessages), perMessage(a.EmojiCount, a.TotalMessages), "%.2f"},
+		{"Среднее настроение", b.Sentiment.Average(), a.Sentiment.Average(), "%+.3f"},
+	}
+}
+
+// perDay returns the average number of messages per day between the first and last message
+func perDay(stats *analyzer.YearStats) float64 {
+	first, last := stats.FirstMessage, stats.LastMessage
+	start := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, first.Location())
+	days := math.Ceil(last.Sub(start).Hours()/24 + 1e-9)
+	if days < 1 {
+		days = 1
+	}
+	return float64(stats.TotalMessages) / days
+}
+
+// formatChange formats the relative change between two values
+func formatChange(before, after float64) string {
+	if before == 0 {
+		if after == 0 {
+			return "0%"
+		}
+		return "новое"
+	}
+	return fmt.Sprintf("%+.1f%%", (after-before)/math.Abs(before)*100)
+}
+
+// formatShift formats how many times a word became more or less frequent
+func formatShift(change float64) string {
+	if change >= 0 {
+		return fmt.Sprintf("×%.1f", math.Pow(2, change))
+	}
+	return fmt.Sprintf("÷%.1f", math.Pow(2, -change))
+}
+
+// periodShortName names a period in table headers
+func periodShortName(p analyzer.Period) string {
+	if p.Kind == analyzer.PeriodRange {
+		return fmt.Sprintf("%s–%s", p.Start.Format("02.01.06"), p.End.AddDate(0, 0, -1).Format("02.01.06"))
+	}
+	return p.Key
+}
+
+// hourWindowShares sums hourly shares into 2-hour windows
+func hourWindowShares(shares [24]float64) [12]float64 {
+	var windows [12]float64
+	for hour, s := range shares {
+		windows[hour/2] += s
+	}
+	return windows
+}
+
+// GenerateComparisonReport writes a markdown report comparing two periods;
+// mask hides obscene words in word lists and message excerpts
+func GenerateComparisonReport(cmp *analyzer.Comparison, chatName, outputDir string, mask bool) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := filepath.Join(outputDir, "comparison_report.md")
+	if err := os.WriteFile(filename, []byte(generateComparisonReport(cmp, chatName, mask)), 0644); err != nil {
+		return fmt.Errorf("failed to write comparison report: %w", err)
+	}
+	fmt.Printf("Создан отчет сравнения: %s\n", filename)
+	return nil
+}
+
+// generateComparisonReport creates markdown content comparing two periods
+func generateComparisonReport(cmp *analyzer.Comparison, chatName string, mask bool) string {
+	var sb strings.Builder
+	before, after := periodShortName(cmp.Before.Period), periodShortName(cmp.After.Period)
+
+	sb.WriteString(fmt.Sprintf("# Сравнение: %s и %s\n\n", periodTitle(cmp.Before.Period), periodTitle(cmp.After.Period)))
+
+	// Metadata section
+	sb.WriteString("## Метаданные чата\n\n")
+	sb.WriteString(fmt.Sprintf("- **Название чата:** %s\n", chatName))
+	sb.WriteString(fmt.Sprintf("- **Первый период:** %s — %s\n",
+		cmp.Before.FirstMessage.Format("02.01.2006"), cmp.Before.LastMessage.Format("02.01.2006")))
+	sb.WriteString(fmt.Sprintf("- **Второй период:** %s — %s\n\n",
+		cmp.After.FirstMessage.Format("02.01.2006"), cmp.After.LastMessage.Format("02.01.2006")))
+
+	// Volume
+	sb.WriteString("## Объем переписки\n\n")
+	sb.WriteString(fmt.Sprintf("| Показатель | %s | %s | Изменение |\n", before, after))
+	sb.WriteString("|------------|----|----|-----------|\n")
+	for _, m := range comparisonMetrics(cmp) {
+		sb.WriteString(fmt.Sprintf("| %s | "+m.format+" | "+m.format+" | %s |\n",
+			m.name, m.before, m.after, formatChange(m.before, m.after)))
+	}
+	sb.WriteString("\n")
+
+	// Users
+	sb.WriteString("## Доли участников\n\n")
+	sb.WriteString(fmt.Sprintf("| Участник | Сообщений (%s) | Доля (%s) | Сообщений (%s) | Доля (%s) | Изменение доли |\n",
+		before, before, after, after))
+	sb.WriteString("|----------|----|----|----|----|----|\n")
+	for i, u := range cmp.Users {
+		if i == 15 {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% | %d | %.1f%% | %+.1f п.п. |\n",
+			u.Name, u.Before, u.BeforeShare*100, u.After, u.AfterShare*100, (u.AfterShare-u.BeforeShare)*100))
+	}
+	sb.WriteString("\n")
+
+	// Words
+	sb.WriteString("## Изменения в словах\n\n")
+	sb.WriteString("Частота — число употреблений на 1000 учтенных слов периода.\n\n")
+	for _, block := range []struct {
+		title string
+		words []analyzer.WordShift
+	}{
+		{"Стали говорить чаще", cmp.RisingWords},
+		{"Стали говорить реже", cmp.FallingWords},
+	} {
+		if len(block.words) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("### %s\n\n", block.title))
+		sb.WriteString(fmt.Sprintf("| # | Слово | %s | %s | Изменение |\n", before, after))
+		sb.WriteString("|---|-------|----|----|-----------|\n")
+		for i, ws := range block.words {
+			sb.WriteString(fmt.Sprintf("| %d | %s | %.2f (%d) | %.2f (%d) | %s |\n",
+				i+1, maskText(ws.DisplayWord(), mask), ws.BeforeRate, ws.Before, ws.AfterRate, ws.Count, formatShift(ws.Change)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Active hours
+	sb.WriteString("## Активные часы\n\n")
+	sb.WriteString(fmt.Sprintf("- **Самый активный период (%s):** %02d:00 — %02d:00\n",
+		before, cmp.Before.MostActiveWindow.StartHour, cmp.Before.MostActiveWindow.EndHour))
+	sb.WriteString(fmt.Sprintf("- **Самый активный период (%s):** %02d:00 — %02d:00\n\n",
+		after, cmp.After.MostActiveWindow.StartHour, cmp.After.MostActiveWindow.EndHour))
+
+	beforeWindows, afterWindows := hourWindowShares(cmp.HourShares[0]), hourWindowShares(cmp.HourShares[1])
+	sb.WriteString(fmt.Sprintf("| Часы | %s | %s | Изменение доли |\n", before, after))
+	sb.WriteString("|------|----|----|----------------|\n")
+	for w := range beforeWindows {
+		sb.WriteString(fmt.Sprintf("| %02d:00-%02d:00 | %.1f%% | %.1f%% | %+.1f п.п. |\n",
+			w*2, w*2+2, beforeWindows[w]*100, afterWindows[w]*100, (afterWindows[w]-beforeWindows[w])*100))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// GenerateComparisonPDF writes a PDF report comparing two periods;
+// mask hides obscene words in word lists and message excerpts
+func GenerateComparisonPDF(cmp *analyzer.Comparison, chatName, outputDir string, mask bool) error {
+	fontPath := findFont()
+	if fontPath == "" {
+		return fmt.Errorf("не найден TTF шрифт с поддержкой кириллицы")
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := filepath.Join(outputDir, "comparison_report.pdf")
+	gen := &PDFGenerator{fontPath: fontPath, mask: mask}
+	if err := gen.generateComparisonPDF(cmp, chatName, filename); err != nil {
+		return fmt.Errorf("failed to generate comparison PDF: %w", err)
+	}
+	fmt.Printf("Создан PDF отчет сравнения: %s\n", filename)
+	return nil
+}
+
+func (g *PDFGenerator) generateComparisonPDF(cmp *analyzer.Comparison, chatName, filename string) error {
+	if err := g.initPDF(); err != nil {
+		return err
+	}
+	before, after := periodShortName(cmp.Before.Period), periodShortName(cmp.After.Period)
+
+	// Title
+	g.writeTitle(fmt.Sprintf("Сравнение: %s и %s", before, after))
+	g.addSpace(10)
+
+	// Metadata
+	g.writeHeader("Метаданные чата")
+	g.writeLine(fmt.Sprintf("Название чата: %s", chatName))
+	g.writeLine(fmt.Sprintf("Первый период: %s", periodTitle(cmp.Before.Period)))
+	g.writeLine(fmt.Sprintf("Второй период: %s", periodTitle(cmp.After.Period)))
+	g.addSpace(10)
+
+	// Volume
+	g.writeHeader("Объем переписки")
+	widths := []float64{180, 90, 90, 90}
+	g.writeTableRow([]string{"Показатель", before, after, "Изменение"}, widths)
+	for _, m := range comparisonMetrics(cmp) {
+		g.writeTableRow([]string{
+			m.name,
+			fmt.Sprintf(m.format, m.before),
+			fmt.Sprintf(m.format, m.after),
+			formatChange(m.before, m.after),
+		}, widths)
+	}
+	g.addSpace(10)
+
+	// Users
+	g.writeHeader("Доли участников")
+	userWidths := []float64{180, 90, 90, 90}
+	g.writeTableRow([]string{"Участник", before, after, "Изменение"}, userWidths)
+	for i, u := range cmp.Users {
+		if i == 15 {
+			break
+		}
+		g.writeTableRow([]string{
+			u.Name,
+			fmt.Sprintf("%d (%.1f%%)", u.Before, u.BeforeShare*100),
+			fmt.Sprintf("%d (%.1f%%)", u.After, u.AfterShare*100),
+			fmt.Sprintf("%+.1f п.п.", (u.AfterShare-u.BeforeShare)*100),
+		}, userWidths)
+	}
+	g.addSpace(10)
+
+	// Words
+	g.writeHeader("Изменения в словах")
+	g.writeLine("Частота — число употреблений на 1000 учтенных слов периода")
+	wordWidths := []float64{30, 180, 90, 90, 60}
+	for _, block := range []struct {
+		title string
+		words []analyzer.WordShift
+	}{
+		{"Стали говорить чаще", cmp.RisingWords},
+		{"Стали говорить реже", cmp.FallingWords},
+	} {
+		if len(block.words) == 0 {
+			continue
+		}
+		g.writeSubHeader(block.title)
+		for i, ws := range block.words {
+			g.writeTableRow([]string{
+				fmt.Sprintf("%d.", i+1),
+				maskText(ws.DisplayWord(), g.mask),
+				fmt.Sprintf("%.2f", ws.BeforeRate),
+				fmt.Sprintf("%.2f", ws.AfterRate),
+				formatShift(ws.Change),
+			}, wordWidths)
+		}
+		g.addSpace(5)
+	}
+	g.addSpace(5)
+
+	// Active hours
+	g.writeHeader("Активные часы")
+	g.writeLine(fmt.Sprintf("Самый активный период: %02d:00 — %02d:00 (%s), %02d:00 — %02d:00 (%s)",
+		cmp.Before.MostActiveWindow.StartHour, cmp.Before.MostActiveWindow.EndHour, before,
+		cmp.After.MostActiveWindow.StartHour, cmp.After.MostActiveWindow.EndHour, after))
+	hourWidths := []float64{120, 90, 90, 90}
+	g.writeTableRow([]string{"Часы", before, after, "Изменение"}, hourWidths)
+	beforeWindows, afterWindows := hourWindowShares(cmp.HourShares[0]), hourWindowShares(cmp.HourShares[1])
+	for w := range beforeWindows {
+		g.writeTableRow([]string{
+			fmt.Sprintf("%02d:00-%02d:00", w*2, w*2+2),
+			fmt.Sprintf("%.1f%%", beforeWindows[w]*100),
+			fmt.Sprintf("%.1f%%", afterWindows[w]*100),
+			fmt.Sprintf("%+.1f п.п.", (afterWindows[w]-beforeWindows[w])*100),
+		}, hourWidths)
+	}
+
+	return g.pdf.WritePdf(filename)
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -11,6 +12,15 @@ import (
 	"telegram_message_analyzer/analyzer"
 )
 
+// maskText hides obscene words of a word list or message excerpt when mask is
+// set. Participant and chat names are never passed here, so they stay readable.
+func maskText(text string, mask bool) string {
+	if !mask {
+		return text
+	}
+	return analyzer.MaskProfanity(text)
+}
+
 // russianMonths maps month numbers to Russian month names
 var russianMonths = map[time.Month]string{
 	time.January:   "Январь",
@@ -27,22 +37,854 @@ var russianMonths = map[time.Month]string{
 	time.December:  "Декабрь",
 }
 
-// GenerateReports creates markdown reports for each year
-func GenerateReports(stats *analyzer.Stats, outputDir string) error {
+// russianWeekdays maps days of the week to Russian names
+var russianWeekdays = map[time.Weekday]string{
+	time.Monday:    "Понедельник",
+	time.Tuesday:   "Вторник",
+	time.Wednesday: "Среда",
+	time.Thursday:  "Четверг",
+	time.Friday:    "Пятница",
+	time.Saturday:  "Суббота",
+	time.Sunday:    "Воскресенье",
+}
+
+// languageNames maps language codes to Russian language names
+var languageNames = map[string]string{
+	analyzer.LangRussian:   "Русский",
+	analyzer.LangUkrainian: "Украинский",
+	analyzer.LangEnglish:   "Английский",
+	analyzer.LangUnknown:   "Не определен",
+}
+
+// formatLanguageMix formats language shares like "Русский 80.0%, Английский 20.0%"
+func formatLanguageMix(counts map[string]int) string {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	parts := make([]string, 0, len(counts))
+	for _, lang := range analyzer.GetSortedLanguages(counts) {
+		parts = append(parts, fmt.Sprintf("%s %.1f%%", languageNames[lang], float64(counts[lang])/float64(total)*100))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatStopWords describes the effective stop word lists of a report
+func formatStopWords(info analyzer.StopWordInfo) string {
+	text := fmt.Sprintf("встроенные (%s)", strings.Join(info.Languages, ", "))
+	if len(info.Added) > 0 {
+		text += fmt.Sprintf("; добавлены: %s", strings.Join(info.Added, ", "))
+	}
+	if len(info.Kept) > 0 {
+		text += fmt.Sprintf("; учитываются: %s", strings.Join(info.Kept, ", "))
+	}
+	return text
+}
+
+// writePhraseTable writes a table of phrases with counts and collocation scores
+func writePhraseTable(sb *strings.Builder, phrases []analyzer.PhraseCount, mask bool) {
+	sb.WriteString("| # | Фраза | Количество | Оценка |\n")
+	sb.WriteString("|---|-------|------------|--------|\n")
+	for i, pc := range phrases {
+		sb.WriteString(fmt.Sprintf("| %d | %s | %d | %.1f |\n", i+1, maskText(pc.DisplayPhrase(), mask), pc.Count, pc.Score))
+	}
+	sb.WriteString("\n")
+}
+
+// writePhraseSection writes top bigrams, trigrams and phrases of main users
+func writePhraseSection(sb *strings.Builder, stats *analyzer.YearStats, mask bool) {
+	sb.WriteString("Фразы ранжированы по логарифмическому правдоподобию (G²): чем выше оценка, тем устойчивее сочетание слов.\n\n")
+
+	if len(stats.TopBigrams) > 0 {
+		sb.WriteString("### Сочетания из двух слов\n\n")
+		writePhraseTable(sb, stats.TopBigrams, mask)
+	}
+	if len(stats.TopTrigrams) > 0 {
+		sb.WriteString("### Сочетания из трех слов\n\n")
+		writePhraseTable(sb, stats.TopTrigrams, mask)
+	}
+
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		if phrases := stats.TopPhrasesByUser[user.Name]; len(phrases) > 0 {
+			sb.WriteString(fmt.Sprintf("### Фразы: %s\n\n", user.Name))
+			writePhraseTable(sb, phrases, mask)
+		}
+	}
+}
+
+// writeSignatureTable writes a table of characteristic words with their scores
+func writeSignatureTable(sb *strings.Builder, words []analyzer.WordScore, mask bool) {
+	sb.WriteString("| # | Слово | Количество | Оценка |\n")
+	sb.WriteString("|---|-------|------------|--------|\n")
+	for i, ws := range words {
+		sb.WriteString(fmt.Sprintf("| %d | %s | %d | %.1f |\n", i+1, maskText(ws.DisplayWord(), mask), ws.Count, ws.Score))
+	}
+	sb.WriteString("\n")
+}
+
+// writeUserSignatures writes the signature words of every main user
+func writeUserSignatures(sb *strings.Builder, stats *analyzer.YearStats, mask bool) {
+	sb.WriteString("Слова, которые участник использует заметно чаще остальных (z-оценка логарифма отношения шансов).\n\n")
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		if words := stats.SignatureWordsByUser[user.Name]; len(words) > 0 {
+			sb.WriteString(fmt.Sprintf("### Слова-маркеры: %s\n\n", user.Name))
+			writeSignatureTable(sb, words, mask)
+		}
+	}
+}
+
+// joinSignatureWords lists up to n characteristic words separated by commas
+func joinSignatureWords(words []analyzer.WordScore, n int, mask bool) string {
+	parts := make([]string, 0, n)
+	for i, ws := range words {
+		if i >= n {
+			break
+		}
+		parts = append(parts, maskText(ws.DisplayWord(), mask))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// perMessage returns count divided by the number of messages
+func perMessage(count, messages int) float64 {
+	if messages == 0 {
+		return 0
+	}
+	return float64(count) / float64(messages)
+}
+
+// joinWordCounts formats up to n items like "😂 12, 👍 7"
+func joinWordCounts(words []analyzer.WordCount, n int, mask bool) string {
+	parts := make([]string, 0, n)
+	for i, wc := range words {
+		if i >= n {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", maskText(wc.DisplayWord(), mask), wc.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// writeEmojiSection writes emoji totals, top emoji and emoji habits of main users
+func writeEmojiSection(sb *strings.Builder, stats *analyzer.YearStats) {
+	sb.WriteString(fmt.Sprintf("- **Всего эмодзи:** %d\n", stats.EmojiCount))
+	sb.WriteString(fmt.Sprintf("- **Эмодзи на сообщение:** %.2f\n\n", perMessage(stats.EmojiCount, stats.TotalMessages)))
+
+	if len(stats.TopEmoji) > 0 {
+		sb.WriteString("| # | Эмодзи | Количество |\n")
+		sb.WriteString("|---|--------|------------|\n")
+		for i, wc := range stats.TopEmoji {
+			sb.WriteString(fmt.Sprintf("| %d | %s | %d |\n", i+1, wc.Word, wc.Count))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("### Эмодзи по участникам\n\n")
+	sb.WriteString("| Участник | Эмодзи | На сообщение | Популярные |\n")
+	sb.WriteString("|----------|--------|--------------|------------|\n")
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.2f | %s |\n",
+			user.Name,
+			stats.EmojiCountByUser[user.Name],
+			perMessage(stats.EmojiCountByUser[user.Name], user.Count),
+			joinWordCounts(stats.TopEmojiByUser[user.Name], 5, false)))
+	}
+	sb.WriteString("\n")
+}
+
+// sentimentBar draws a sentiment score as a row of "+" or "−" signs
+func sentimentBar(score float64) string {
+	n := int(math.Round(math.Abs(score) * 20))
+	if score < 0 {
+		return strings.Repeat("−", n)
+	}
+	return strings.Repeat("+", n)
+}
+
+// writeSentimentSection writes average mood, monthly curves and the moodiest days
+func writeSentimentSection(sb *strings.Builder, stats *analyzer.YearStats) {
+	s := stats.Sentiment
+	sb.WriteString("Оценка настроения от −1 (негатив) до 1 (позитив) по словарю эмоциональных слов и эмодзи.\n\n")
+	sb.WriteString(fmt.Sprintf("- **Среднее настроение:** %+.3f\n", s.Average()))
+	sb.WriteString(fmt.Sprintf("- **Позитивных сообщений:** %d (%.1f%%)\n", s.Positive, perMessage(s.Positive, s.Messages)*100))
+	sb.WriteString(fmt.Sprintf("- **Негативных сообщений:** %d (%.1f%%)\n\n", s.Negative, perMessage(s.Negative, s.Messages)*100))
+
+	mainUsers := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
+
+	sb.WriteString("### Настроение по участникам\n\n")
+	sb.WriteString("| Участник | Среднее | Позитивных | Негативных |\n")
+	sb.WriteString("|----------|---------|------------|------------|\n")
+	for _, user := range mainUsers {
+		us := stats.SentimentByUser[user.Name]
+		sb.WriteString(fmt.Sprintf("| %s | %+.3f | %d | %d |\n", user.Name, us.Average(), us.Positive, us.Negative))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### Настроение по месяцам\n\n")
+	sb.WriteString("| Месяц | Среднее | График |")
+	for _, user := range mainUsers {
+		sb.WriteString(fmt.Sprintf(" %s |", user.Name))
+	}
+	sb.WriteString("\n|-------|---------|--------|")
+	for range mainUsers {
+		sb.WriteString("------|")
+	}
+	sb.WriteString("\n")
+	for _, m := range sortMonths(stats.MonthlyActivity) {
+		score := stats.SentimentByMonth[m.key].Average()
+		sb.WriteString(fmt.Sprintf("| %s | %+.3f | %s |", formatMonth(m.key), score, sentimentBar(score)))
+		for _, user := range mainUsers {
+			if us := stats.SentimentByUserMonth[user.Name][m.key]; us != nil {
+				sb.WriteString(fmt.Sprintf(" %+.3f |", us.Average()))
+			} else {
+				sb.WriteString(" — |")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	writeDaySentiment(sb, "Самые позитивные дни", stats.MostPositiveDays)
+	writeDaySentiment(sb, "Самые негативные дни", stats.MostNegativeDays)
+}
+
+// writeDaySentiment writes a table of days with their average mood
+func writeDaySentiment(sb *strings.Builder, title string, days []analyzer.DaySentiment) {
+	if len(days) == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("### %s\n\n", title))
+	sb.WriteString("| Дата | Настроение | Сообщений |\n")
+	sb.WriteString("|------|------------|-----------|\n")
+	for _, d := range days {
+		sb.WriteString(fmt.Sprintf("| %s | %+.3f | %d |\n", d.Date.Format("02.01.2006"), d.Score, d.Messages))
+	}
+	sb.WriteString("\n")
+}
+
+// topicLabel lists the key words of a topic
+func topicLabel(topic analyzer.Topic, n int, mask bool) string {
+	return joinSignatureWords(topic.Words, n, mask)
+}
+
+// writeTopicSection writes topics with their key words and monthly prevalence
+func writeTopicSection(sb *strings.Builder, stats *analyzer.Stats, months []monthEntry, mask bool) {
+	sb.WriteString("Темы найдены автоматически (неотрицательное матричное разложение по дням переписки).\n\n")
+	sb.WriteString("| Тема | Ключевые слова | Доля |\n")
+	sb.WriteString("|------|----------------|------|\n")
+	for _, topic := range stats.Topics {
+		sb.WriteString(fmt.Sprintf("| %d | %s | %.1f%% |\n", topic.ID, topicLabel(topic, 8, mask), topic.Share*100))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### Темы по месяцам\n\n")
+	sb.WriteString("| Месяц |")
+	for _, topic := range stats.Topics {
+		sb.WriteString(fmt.Sprintf(" %d |", topic.ID))
+	}
+	sb.WriteString(" Главная тема |\n|-------|")
+	for range stats.Topics {
+		sb.WriteString("---|")
+	}
+	sb.WriteString("--------------|\n")
+	for _, m := range months {
+		shares := stats.TopicsByMonth[m.key]
+		if shares == nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s |", formatMonth(m.key)))
+		top := 0
+		for t, share := range shares {
+			sb.WriteString(fmt.Sprintf(" %.0f%% |", share*100))
+			if share > shares[top] {
+				top = t
+			}
+		}
+		sb.WriteString(fmt.Sprintf(" %s |\n", topicLabel(stats.Topics[top], 3, mask)))
+	}
+	sb.WriteString("\n")
+}
+
+// trendBar draws a count as a bar scaled to the largest count of the series
+func trendBar(count, max int) string {
+	if max == 0 {
+		return ""
+	}
+	return strings.Repeat("█", int(math.Round(float64(count)/float64(max)*20)))
+}
+
+// termMonthMax returns the largest monthly count of a term within the given months
+func termMonthMax(term analyzer.TermStats, months []monthEntry) (total, max int) {
+	for _, m := range months {
+		count := term.ByMonth[m.key]
+		total += count
+		if count > max {
+			max = count
+		}
+	}
+	return total, max
+}
+
+// writeTrackingSection writes the monthly trend of every tracked term within a period
+func writeTrackingSection(sb *strings.Builder, terms []analyzer.TermStats, stats *analyzer.YearStats) {
+	months := sortMonths(stats.MonthlyActivity)
+	mainUsers := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
+
+	for _, term := range terms {
+		total, max := termMonthMax(term, months)
+		sb.WriteString(fmt.Sprintf("### %s\n\n", term.Name))
+		sb.WriteString(fmt.Sprintf("Шаблон: `%s` — %d сообщений (%.2f на 1000)\n\n",
+			term.Pattern, total, perMessage(total, stats.TotalMessages)*1000))
+		if total == 0 {
+			continue
+		}
+
+		sb.WriteString("| Месяц | Сообщений | На 1000 |")
+		for _, user := range mainUsers {
+			sb.WriteString(fmt.Sprintf(" %s |", user.Name))
+		}
+		sb.WriteString(" График |\n|-------|-----------|---------|")
+		for range mainUsers {
+			sb.WriteString("------|")
+		}
+		sb.WriteString("--------|\n")
+		for _, m := range months {
+			count := term.ByMonth[m.key]
+			sb.WriteString(fmt.Sprintf("| %s | %d | %.2f |", formatMonth(m.key), count, perMessage(count, m.count)*1000))
+			for _, user := range mainUsers {
+				sb.WriteString(fmt.Sprintf(" %d |", term.ByUserMonth[user.Name][m.key]))
+			}
+			sb.WriteString(fmt.Sprintf(" %s |\n", trendBar(count, max)))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// truncateText shortens a text to n characters and puts it on one line
+func truncateText(text string, n int, mask bool) string {
+	text = maskText(strings.Join(strings.Fields(text), " "), mask)
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:n])) + "…"
+}
+
+// formatBucket names a length histogram bucket like "11–30" or "1001+"
+func formatBucket(b analyzer.LengthBucket) string {
+	if b.Max == 0 {
+		return fmt.Sprintf("%d+", b.Min)
+	}
+	return fmt.Sprintf("%d–%d", b.Min, b.Max)
+}
+
+// writeLengthSection writes the length distribution, histogram and longest messages of a period
+func writeLengthSection(sb *strings.Builder, stats *analyzer.YearStats, mask bool) {
+	l := stats.Lengths
+	sb.WriteString("Длина считается в символах. Медиана и перцентили не зависят от нескольких очень длинных сообщений.\n\n")
+	sb.WriteString(fmt.Sprintf("- **Медиана:** %d\n", l.Median))
+	sb.WriteString(fmt.Sprintf("- **90-й перцентиль:** %d\n", l.P90))
+	sb.WriteString(fmt.Sprintf("- **99-й перцентиль:** %d\n", l.P99))
+	sb.WriteString(fmt.Sprintf("- **Максимум:** %d\n\n", l.Max))
+
+	sb.WriteString("| Символов | Сообщений | Доля | График |\n")
+	sb.WriteString("|----------|-----------|------|--------|\n")
+	maxCount := 0
+	for _, b := range stats.LengthHistogram {
+		maxCount = max(maxCount, b.Count)
+	}
+	for _, b := range stats.LengthHistogram {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% | %s |\n",
+			formatBucket(b), b.Count, perMessage(b.Count, stats.TotalMessages)*100, trendBar(b.Count, maxCount)))
+	}
+	sb.WriteString("\n")
+
+	mainUsers := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
+
+	sb.WriteString("### Длина сообщений по участникам\n\n")
+	sb.WriteString("| Участник | Медиана | 90-й перц. | Максимум | Слов на сообщение |\n")
+	sb.WriteString("|----------|---------|------------|----------|-------------------|\n")
+	for _, user := range mainUsers {
+		ul := stats.LengthsByUser[user.Name]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %.1f |\n",
+			user.Name, ul.Median, ul.P90, ul.Max, perMessage(stats.WordCountByUser[user.Name], user.Count)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### Самые длинные сообщения\n\n")
+	for _, user := range mainUsers {
+		m, ok := stats.LongestMessageByUser[user.Name]
+		if !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("**%s** (%s, %d символов):\n\n> %s\n\n",
+			user.Name, m.Date.Format("02.01.2006"), m.Length, truncateText(m.Text, 300, mask)))
+	}
+}
+
+// formatMTLD shows MTLD or a dash when the text was too short for it
+func formatMTLD(v analyzer.VocabularyStats) string {
+	if v.MTLD == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.1f", v.MTLD)
+}
+
+// writeVocabularySection writes vocabulary richness of a period and the words it introduced
+func writeVocabularySection(sb *strings.Builder, stats *analyzer.YearStats, mask bool) {
+	sb.WriteString("TTR — доля разных слов среди всех слов, сильно падает на длинных текстах. ")
+	sb.WriteString("MTLD — средняя длина отрезка текста, на котором слова почти не повторяются; от объема переписки зависит слабо, больше — богаче словарь.\n\n")
+	sb.WriteString("| Участник | Слов | Разных слов | TTR | MTLD |\n")
+	sb.WriteString("|----------|------|-------------|-----|------|\n")
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		v := stats.VocabularyByUser[user.Name]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %.3f | %s |\n", user.Name, v.Tokens, v.Types, v.TTR, formatMTLD(v)))
+	}
+	v := stats.Vocabulary
+	sb.WriteString(fmt.Sprintf("| **Все** | %d | %d | %.3f | %s |\n\n", v.Tokens, v.Types, v.TTR, formatMTLD(v)))
+
+	if len(stats.NewWords) > 0 {
+		sb.WriteString("### Новые слова\n\n")
+		sb.WriteString("Слова, которые впервые появились в чате в этом периоде.\n\n")
+		sb.WriteString("| Слово | Употреблений | Кто ввел | Когда |\n")
+		sb.WriteString("|-------|--------------|----------|-------|\n")
+		for _, w := range stats.NewWords {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s |\n", maskText(w.DisplayWord(), mask), w.Count, w.User, w.Date.Format("02.01.2006")))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// formatBurstDates shows a burst as one date or a date range
+func formatBurstDates(b analyzer.Burst) string {
+	if b.Days() == 1 {
+		return b.Start.Format("02.01.2006")
+	}
+	return fmt.Sprintf("%s — %s", b.Start.Format("02.01.2006"), b.End.Format("02.01.2006"))
+}
+
+// userNames returns the names of people in the order given
+func userNames(users []analyzer.UserStat) []string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = user.Name
+	}
+	return names
+}
+
+// formatUserCounts lists up to n people with their counts, like "Борис 30, Аня 12"
+func formatUserCounts(users []analyzer.UserStat, n int) string {
+	parts := make([]string, 0, n)
+	for i, u := range users {
+		if i >= n {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", u.Name, u.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// writeBurstSection writes up to n bursts of activity, strongest first
+func writeBurstSection(sb *strings.Builder, bursts []analyzer.Burst, n int, mask bool) {
+	if len(bursts) == 0 {
+		sb.WriteString("Необычных всплесков активности не найдено.\n\n")
+		return
+	}
+	sb.WriteString("Дни, когда сообщений было намного больше, чем обычно за предыдущие четыре недели (z-оценка от 3). Соседние такие дни объединены.\n\n")
+	sb.WriteString("| Даты | Сообщений | Обычно | Оценка | Участники | Характерные слова |\n")
+	sb.WriteString("|------|-----------|--------|--------|-----------|-------------------|\n")
+	for i, b := range bursts {
+		if i >= n {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.0f | %.1f | %s | %s |\n",
+			formatBurstDates(b), b.Messages, b.Expected, b.Score, formatUserCounts(b.Users, 3), joinSignatureWords(b.Words, 8, mask)))
+	}
+	sb.WriteString("\n")
+}
+
+// formatRun describes a monologue like "Аня, 12.03.2023 14:05–14:40, 17 сообщений"
+func formatRun(run analyzer.MessageRun) string {
+	end := run.End.Format("15:04")
+	if run.End.Format("2006-01-02") != run.Start.Format("2006-01-02") {
+		end = run.End.Format("02.01.2006 15:04")
+	}
+	return fmt.Sprintf("%s, %s–%s, %d сообщений", run.From, run.Start.Format("02.01.2006 15:04"), end, run.Messages)
+}
+
+// writeRunSection writes how long people talk without being interrupted
+func writeRunSection(sb *strings.Builder, stats *analyzer.YearStats) {
+	sb.WriteString("Серия — несколько сообщений подряд от одного человека без перерывов дольше часа. «Дописывает без ответа» — доля серий, в которых человек отправил еще одно сообщение, не дождавшись ответа.\n\n")
+	sb.WriteString(fmt.Sprintf("- **Серий:** %d\n", stats.Runs))
+	sb.WriteString(fmt.Sprintf("- **Сообщений в серии в среднем:** %.2f\n", perMessage(stats.RunMessages, stats.Runs)))
+	if stats.LongestRun.Messages > 0 {
+		sb.WriteString(fmt.Sprintf("- **Самый длинный монолог:** %s\n", formatRun(stats.LongestRun)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("| Участник | Серий | Сообщений в серии | Дописывает без ответа | Самый длинный монолог |\n")
+	sb.WriteString("|----------|-------|-------------------|-----------------------|-----------------------|\n")
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		run := stats.LongestRunByUser[user.Name]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.2f | %.1f%% | %d (%s) |\n",
+			user.Name, stats.RunsByUser[user.Name], stats.AvgRunLength(user.Name),
+			stats.FollowUpRate(user.Name)*100, run.Messages, run.Start.Format("02.01.2006")))
+	}
+	sb.WriteString("\n")
+}
+
+// initiationWindows are the parts of the day conversations are grouped by
+var initiationWindows = []struct{ start, end int }{
+	{0, 4}, {4, 8}, {8, 12}, {12, 16}, {16, 20}, {20, 24},
+}
+
+// weekdaysFromMonday returns the days of the week in Russian order
+func weekdaysFromMonday() []time.Weekday {
+	days := make([]time.Weekday, 0, 7)
+	for i := 1; i <= 7; i++ {
+		days = append(days, time.Weekday(i%7))
+	}
+	return days
+}
+
+// formatInitiations shows conversations started in a month with the share of the month
+func formatInitiations(count, total int) string {
+	if count == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%d (%.0f%%)", count, perMessage(count, total)*100)
+}
+
+// writeInitiatorSection writes who starts conversations after a long silence and when
+func writeInitiatorSection(sb *strings.Builder, stats *analyzer.YearStats, silence time.Duration) {
+	sb.WriteString(fmt.Sprintf("Начало разговора — первое сообщение после тишины дольше %s.\n\n", formatSilence(silence)))
+	sb.WriteString(fmt.Sprintf("- **Разговоров:** %d\n\n", stats.Conversations))
+	if stats.Conversations == 0 {
+		return
+	}
+
+	users := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
+	names := userNames(users)
+	sb.WriteString("| Участник | Начал разговоров | Доля | Чаще всего в | Чаще всего по дням |\n")
+	sb.WriteString("|----------|------------------|------|--------------|--------------------|\n")
+	for _, user := range users {
+		in := stats.InitiationsByUser[user.Name]
+		if in == nil {
+			sb.WriteString(fmt.Sprintf("| %s | 0 | 0.0%% | — | — |\n", user.Name))
+			continue
+		}
+		hour := in.FavoriteHour()
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% | %02d:00-%02d:00 | %s |\n", user.Name, in.Count,
+			stats.InitiationShare(user.Name)*100, hour, hour+1, russianWeekdays[in.FavoriteWeekday()]))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### Начало разговоров по времени суток\n\n")
+	writeTableHeader(sb, "Время", names)
+	for _, w := range initiationWindows {
+		sb.WriteString(fmt.Sprintf("| %02d:00-%02d:00 |", w.start, w.end))
+		for _, user := range users {
+			count := 0
+			if in := stats.InitiationsByUser[user.Name]; in != nil {
+				for h := w.start; h < w.end; h++ {
+					count += in.Hours[h]
+				}
+			}
+			sb.WriteString(fmt.Sprintf(" %d |", count))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### Начало разговоров по дням недели\n\n")
+	writeTableHeader(sb, "День", names)
+	for _, day := range weekdaysFromMonday() {
+		sb.WriteString(fmt.Sprintf("| %s |", russianWeekdays[day]))
+		for _, user := range users {
+			count := 0
+			if in := stats.InitiationsByUser[user.Name]; in != nil {
+				count = in.Weekdays[day]
+			}
+			sb.WriteString(fmt.Sprintf(" %d |", count))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### Кто пишет первым по месяцам\n\n")
+	writeTableHeader(sb, "Месяц", append([]string{"Разговоров"}, names...))
+	for _, m := range sortMonths(stats.MonthlyActivity) {
+		total := stats.InitiationsInMonth(m.key)
+		sb.WriteString(fmt.Sprintf("| %s | %d |", formatMonth(m.key), total))
+		for _, user := range users {
+			count := 0
+			if in := stats.InitiationsByUser[user.Name]; in != nil {
+				count = in.Months[m.key]
+			}
+			sb.WriteString(fmt.Sprintf(" %s |", formatInitiations(count, total)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+// formatDuration shows a waiting time like "40 сек", "12 мин", "3 ч 5 мин" or "2 дн 4 ч"
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%d сек", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%d мин", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%d ч %d мин", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%d дн %d ч", int(d.Hours())/24, int(d.Hours())%24)
+}
+
+// formatSilence shows a pause set in whole hours or days like "6 ч" or "2 дн"
+func formatSilence(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%d дн", int(d.Hours())/24)
+	case d%time.Hour == 0 && d < 24*time.Hour:
+		return fmt.Sprintf("%d ч", int(d.Hours()))
+	}
+	return formatDuration(d)
+}
+
+// formatAnswerTime shows the average time to answer or a dash without answers
+func formatAnswerTime(stats *analyzer.YearStats, user string) string {
+	if stats.AnsweredByUser[user] == 0 {
+		return "—"
+	}
+	return formatDuration(stats.AvgAnswerTime(user))
+}
+
+// writeQuestionSection writes how many questions people ask and how soon they are answered
+func writeQuestionSection(sb *strings.Builder, stats *analyzer.YearStats) {
+	questions, answered := stats.QuestionTotals()
+	sb.WriteString("Вопрос — сообщение со знаком вопроса в конце или начинающееся с вопросительного слова. ")
+	sb.WriteString("Ответом считается ответ на сообщение с вопросом, а если его нет — первое сообщение другого участника, пока разговор не прервался больше чем на час.\n\n")
+	sb.WriteString(fmt.Sprintf("- **Вопросов:** %d (%.1f%% сообщений)\n", questions, perMessage(questions, stats.TotalMessages)*100))
+	sb.WriteString(fmt.Sprintf("- **С ответом:** %d (%.1f%%)\n\n", answered, perMessage(answered, questions)*100))
+
+	sb.WriteString("| Участник | Вопросов | Доля сообщений | С ответом | Без ответа | Среднее время ответа |\n")
+	sb.WriteString("|----------|----------|----------------|-----------|------------|----------------------|\n")
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		asked, got := stats.QuestionsByUser[user.Name], stats.AnsweredByUser[user.Name]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% | %d | %d | %s |\n",
+			user.Name, asked, perMessage(asked, user.Count)*100, got, asked-got, formatAnswerTime(stats, user.Name)))
+	}
+	sb.WriteString("\n")
+}
+
+// formatFunniest shows whose messages make a person laugh most often
+func formatFunniest(stats *analyzer.YearStats, user string) string {
+	name, count := stats.FunniestFor(user)
+	if name == "" {
+		return "—"
+	}
+	return fmt.Sprintf("%s (%d)", name, count)
+}
+
+// writeLaughterSection writes who laughs and whose messages make others laugh
+func writeLaughterSection(sb *strings.Builder, stats *analyzer.YearStats) {
+	sb.WriteString("Смехом считаются «ахаха», «хаха», «хехе», «haha» в любом написании, «лол», «ору», «lol», «))» и «xD». ")
+	sb.WriteString("«Смешит» — сколько раз другие ответили смехом на сообщение участника: ответом на него или сразу после его последнего сообщения в том же разговоре.\n\n")
+	sb.WriteString("| Участник | Смеется | Доля сообщений | Смешит | Чаще всего смеется над |\n")
+	sb.WriteString("|----------|---------|----------------|--------|------------------------|\n")
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		laughs := stats.LaughsByUser[user.Name]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% | %d | %s |\n",
+			user.Name, laughs, perMessage(laughs, user.Count)*100,
+			stats.LaughTriggersByUser[user.Name], formatFunniest(stats, user.Name)))
+	}
+	sb.WriteString("\n")
+}
+
+// writeProfanitySection writes who swears the most and which words they use
+func writeProfanitySection(sb *strings.Builder, stats *analyzer.YearStats, mask bool) {
+	total := stats.ProfanityTotal()
+	sb.WriteString(fmt.Sprintf("- **Матерных слов:** %d (%.1f на 100 сообщений)\n", total, perMessage(total, stats.TotalMessages)*100))
+	if len(stats.TopProfanity) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Самые частые:** %s\n", joinWordCounts(stats.TopProfanity, 10, mask)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("| Участник | Матерных слов | На 100 сообщений | Частые слова |\n")
+	sb.WriteString("|----------|---------------|------------------|--------------|\n")
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		count := stats.ProfanityCountByUser[user.Name]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f | %s |\n",
+			user.Name, count, perMessage(count, user.Count)*100, joinWordCounts(stats.TopProfanityByUser[user.Name], 3, mask)))
+	}
+	sb.WriteString("\n")
+}
+
+// writeDuplicateSection writes the texts that were sent several times
+func writeDuplicateSection(sb *strings.Builder, stats *analyzer.Stats, n int, mask bool) {
+	if len(stats.Duplicates) == 0 {
+		sb.WriteString("Повторяющихся сообщений не найдено.\n\n")
+		return
+	}
+	sb.WriteString("Сообщения длиннее 30 символов, которые отправлялись несколько раз целиком или с небольшими изменениями (MinHash по фрагментам из 5 символов).\n\n")
+	sb.WriteString(fmt.Sprintf("- **Групп повторов:** %d\n", len(stats.Duplicates)))
+	sb.WriteString(fmt.Sprintf("- **Повторных сообщений:** %d (%.1f%%)\n",
+		stats.DuplicateMessages(), perMessage(stats.DuplicateMessages(), stats.Overall.TotalMessages)*100))
+	if stats.DuplicatesExcluded {
+		sb.WriteString("- Повторы не учитываются в подсчете слов\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("| Текст | Раз | Вариантов | Кто отправлял | Первый раз | Последний раз |\n")
+	sb.WriteString("|-------|-----|-----------|---------------|------------|---------------|\n")
+	for i, g := range stats.Duplicates {
+		if i >= n {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %s | %s | %s |\n",
+			strings.ReplaceAll(truncateText(g.Text, 80, mask), "|", "\\|"), g.Messages, g.Variants, formatUserCounts(g.Senders, 3),
+			g.First.Format("02.01.2006"), g.Last.Format("02.01.2006")))
+	}
+	sb.WriteString("\n")
+}
+
+// formatTypos lists likely misspellings like "превет → привет"
+func formatTypos(typos []analyzer.Typo, n int, mask bool) string {
+	parts := make([]string, 0, n)
+	for i, t := range typos {
+		if i >= n {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s → %s", maskText(t.Word, mask), maskText(t.Correct, mask)))
+	}
+	if len(parts) == 0 {
+		return "—"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// styleRows lists the writing habits of users as table rows, one column per user
+func styleRows(stats *analyzer.YearStats, users []analyzer.UserStat) [][]string {
+	rows := [][]string{
+		{"С заглавной буквы"}, {"Капсом"}, {"Знак препинания в конце"}, {"Запятых на сообщение"},
+		{"«!» на сообщение"}, {"«?» на сообщение"}, {"Многоточий на сообщение"}, {"Со скобочкой «)»"},
+		{"Эмодзи на сообщение"}, {"Слов в предложении"}, {"Латиница"},
+	}
+	for _, user := range users {
+		st := stats.StyleByUser[user.Name]
+		if st == nil {
+			st = &analyzer.StyleStats{}
+		}
+		values := []string{
+			fmt.Sprintf("%.1f%%", st.CapitalizedShare()*100),
+			fmt.Sprintf("%.1f%%", st.Share(st.AllCaps)*100),
+			fmt.Sprintf("%.1f%%", st.Share(st.EndPunctuation)*100),
+			fmt.Sprintf("%.2f", st.Share(st.Commas)),
+			fmt.Sprintf("%.2f", st.Share(st.Exclamations)),
+			fmt.Sprintf("%.2f", st.Share(st.QuestionMarks)),
+			fmt.Sprintf("%.2f", st.Share(st.Ellipses)),
+			fmt.Sprintf("%.1f%%", st.Share(st.Smileys)*100),
+			fmt.Sprintf("%.2f", perMessage(stats.EmojiCountByUser[user.Name], user.Count)),
+			fmt.Sprintf("%.1f", st.SentenceLength()),
+			fmt.Sprintf("%.1f%%", st.LatinShare()*100),
+		}
+		for r, v := range values {
+			rows[r] = append(rows[r], v)
+		}
+	}
+	return rows
+}
+
+// writeStyleSection writes a table comparing the writing habits of main users
+func writeStyleSection(sb *strings.Builder, stats *analyzer.YearStats, mask bool) {
+	users := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
+	names := userNames(users)
+	sb.WriteString("Доли — от сообщений участника, латиница — доля латинских букв среди всех букв.\n\n")
+	writeTableHeader(sb, "Показатель", names)
+	for _, row := range styleRows(stats, users) {
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### Возможные опечатки\n\n")
+	sb.WriteString("Редкие слова, которые отличаются от частого слова одной буквой не в окончании: пропущенной, удвоенной, соседней на клавиатуре или перепутанной безударной гласной (е/и, а/о).\n\n")
+	sb.WriteString("| Участник | Опечатки |\n")
+	sb.WriteString("|----------|----------|\n")
+	for _, user := range users {
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", user.Name, formatTypos(stats.TyposByUser[user.Name], 5, mask)))
+	}
+	sb.WriteString("\n")
+}
+
+// writeLanguageSection writes the language mix table of a period
+func writeLanguageSection(sb *strings.Builder, stats *analyzer.YearStats) {
+	sb.WriteString("| Язык | Сообщений | Доля |\n")
+	sb.WriteString("|------|-----------|------|\n")
+	for _, lang := range analyzer.GetSortedLanguages(stats.MessagesByLanguage) {
+		count := stats.MessagesByLanguage[lang]
+		percentage := float64(count) / float64(stats.TotalMessages) * 100
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% |\n", languageNames[lang], count, percentage))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### Языки по участникам\n\n")
+	sb.WriteString("| Участник | Языки |\n")
+	sb.WriteString("|----------|-------|\n")
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", user.Name, formatLanguageMix(stats.LanguagesByUser[user.Name])))
+	}
+	sb.WriteString("\n")
+}
+
+// periodTitle names a report period, e.g. "2023 год", "2 квартал 2023/24" or "март 2023"
+func periodTitle(p analyzer.Period) string {
+	switch p.Kind {
+	case analyzer.PeriodQuarter:
+		year, quarter, _ := strings.Cut(p.Key, "-Q")
+		return fmt.Sprintf("%s квартал %s", quarter, year)
+	case analyzer.PeriodMonth:
+		return strings.ToLower(russianMonths[p.Start.Month()]) + fmt.Sprintf(" %d", p.Start.Year())
+	case analyzer.PeriodWeek:
+		return fmt.Sprintf("неделю %s (%s — %s)", p.Key,
+			p.Start.Format("02.01.2006"), p.End.AddDate(0, 0, -1).Format("02.01.2006"))
+	case analyzer.PeriodRange:
+		return fmt.Sprintf("период %s — %s",
+			p.Start.Format("02.01.2006"), p.End.AddDate(0, 0, -1).Format("02.01.2006"))
+	}
+	return fmt.Sprintf("%s год", p.Key)
+}
+
+// periodSignatureTitle describes what the characteristic words of a period are compared with
+func periodSignatureTitle(p analyzer.Period) string {
+	if p.Kind == analyzer.PeriodYear {
+		return fmt.Sprintf("Темы %s года (в сравнении с другими годами)", p.Key)
+	}
+	return "Темы периода (в сравнении с другими периодами)"
+}
+
+// periodFilename returns the report file name of a period without extension
+func periodFilename(p analyzer.Period) string {
+	return strings.ReplaceAll(p.Key, "/", "-") + "_report"
+}
+
+// GenerateReports creates markdown reports for each period;
+// mask hides obscene words in word lists and message excerpts
+func GenerateReports(stats *analyzer.Stats, outputDir string, mask bool) error {
 	// Create output directory if not exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Generate report for each year
-	for _, year := range stats.GetSortedYears() {
-		yearStats := stats.ByYear[year]
-		filename := filepath.Join(outputDir, fmt.Sprintf("%d_report.md", year))
+	// Generate report for each period
+	for _, key := range stats.GetSortedPeriods() {
+		periodStats := stats.ByPeriod[key]
+		filename := filepath.Join(outputDir, periodFilename(periodStats.Period)+".md")
 
-		content := generateYearReport(stats.ChatName, stats.ChatType, yearStats)
+		content := generateYearReport(stats, periodStats, mask)
 
 		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
-			return fmt.Errorf("failed to write report for %d: %w", year, err)
+			return fmt.Errorf("failed to write report for %s: %w", key, err)
 		}
 
 		fmt.Printf("Создан отчет: %s\n", filename)
@@ -50,7 +892,7 @@ func GenerateReports(stats *analyzer.Stats, outputDir string) error {
 
 	// Generate overall report
 	overallFilename := filepath.Join(outputDir, "overall_report.md")
-	overallContent := generateOverallReport(stats)
+	overallContent := generateOverallReport(stats, mask)
 	if err := os.WriteFile(overallFilename, []byte(overallContent), 0644); err != nil {
 		return fmt.Errorf("failed to write overall report: %w", err)
 	}
@@ -59,23 +901,24 @@ func GenerateReports(stats *analyzer.Stats, outputDir string) error {
 	return nil
 }
 
-// generateYearReport creates markdown content for a specific year
-func generateYearReport(chatName, chatType string, stats *analyzer.YearStats) string {
+// generateYearReport creates markdown content for a specific year or period
+func generateYearReport(all *analyzer.Stats, stats *analyzer.YearStats, mask bool) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("# Отчет по чату за %d год\n\n", stats.Year))
+	sb.WriteString(fmt.Sprintf("# Отчет по чату за %s\n\n", periodTitle(stats.Period)))
 
 	// Metadata section
 	sb.WriteString("## Метаданные чата\n\n")
-	sb.WriteString(fmt.Sprintf("- **Название чата:** %s\n", chatName))
-	sb.WriteString(fmt.Sprintf("- **Тип:** %s\n", chatType))
+	sb.WriteString(fmt.Sprintf("- **Название чата:** %s\n", all.ChatName))
+	sb.WriteString(fmt.Sprintf("- **Тип:** %s\n", all.ChatType))
 	sb.WriteString(fmt.Sprintf("- **Период:** %s — %s\n",
 		stats.FirstMessage.Format("02.01.2006"),
 		stats.LastMessage.Format("02.01.2006")))
 	sb.WriteString(fmt.Sprintf("- **Всего сообщений:** %d\n", stats.TotalMessages))
 	sb.WriteString(fmt.Sprintf("- **Ответов:** %d\n", stats.RepliesCount))
 	sb.WriteString(fmt.Sprintf("- **Пересланных:** %d\n", stats.ForwardedCount))
-	sb.WriteString(fmt.Sprintf("- **Средняя длина сообщения:** %.1f символов\n\n", stats.AvgMessageLength))
+	sb.WriteString(fmt.Sprintf("- **Средняя длина сообщения:** %.1f символов\n", stats.AvgMessageLength))
+	sb.WriteString(fmt.Sprintf("- **Стоп-слова:** %s\n\n", formatStopWords(all.StopWords)))
 
 	// Messages by user
 	sb.WriteString("## Сообщения по участникам\n\n")
@@ -101,6 +944,34 @@ func generateYearReport(chatName, chatType string, stats *analyzer.YearStats) st
 	}
 	sb.WriteString("\n")
 
+	// Who starts conversations
+	sb.WriteString("## Кто пишет первым\n\n")
+	writeInitiatorSection(&sb, stats, all.InitiatorSilence)
+
+	// Runs of messages
+	sb.WriteString("## Серии сообщений\n\n")
+	writeRunSection(&sb, stats)
+
+	// Questions
+	sb.WriteString("## Вопросы\n\n")
+	writeQuestionSection(&sb, stats)
+
+	// Laughter
+	sb.WriteString("## Смех\n\n")
+	writeLaughterSection(&sb, stats)
+
+	// Profanity
+	sb.WriteString("## Мат\n\n")
+	writeProfanitySection(&sb, stats, mask)
+
+	// Message lengths
+	sb.WriteString("## Длина сообщений\n\n")
+	writeLengthSection(&sb, stats, mask)
+
+	// Vocabulary
+	sb.WriteString("## Словарный запас\n\n")
+	writeVocabularySection(&sb, stats, mask)
+
 	// Top 20 words by user
 	sb.WriteString("## Топ-20 популярных слов по участникам\n\n")
 
@@ -113,13 +984,53 @@ func generateYearReport(chatName, chatType string, stats *analyzer.YearStats) st
 			sb.WriteString("| # | Слово | Количество |\n")
 			sb.WriteString("|---|-------|------------|\n")
 			for i, wc := range topWords {
-				sb.WriteString(fmt.Sprintf("| %d | %s | %d |\n", i+1, wc.Word, wc.Count))
+				sb.WriteString(fmt.Sprintf("| %d | %s | %d |\n", i+1, maskText(wc.DisplayWord(), mask), wc.Count))
 			}
 			sb.WriteString("\n")
 		}
 	}
 	sb.WriteString("\n")
 
+	// Phrases
+	sb.WriteString("## Популярные фразы\n\n")
+	writePhraseSection(&sb, stats, mask)
+
+	// Topics
+	if len(all.Topics) > 0 {
+		sb.WriteString("## Темы переписки\n\n")
+		writeTopicSection(&sb, all, sortMonths(stats.MonthlyActivity), mask)
+	}
+
+	// Tracked terms
+	if len(all.Terms) > 0 {
+		sb.WriteString("## Отслеживаемые слова\n\n")
+		writeTrackingSection(&sb, all.Terms, stats)
+	}
+
+	// Signature words
+	sb.WriteString("## Характерные слова\n\n")
+	if len(stats.SignatureWords) > 0 {
+		sb.WriteString(fmt.Sprintf("### %s\n\n", periodSignatureTitle(stats.Period)))
+		writeSignatureTable(&sb, stats.SignatureWords, mask)
+	}
+	writeUserSignatures(&sb, stats, mask)
+
+	// Emoji
+	sb.WriteString("## Эмодзи\n\n")
+	writeEmojiSection(&sb, stats)
+
+	// Sentiment
+	sb.WriteString("## Настроение\n\n")
+	writeSentimentSection(&sb, stats)
+
+	// Language mix
+	sb.WriteString("## Языки сообщений\n\n")
+	writeLanguageSection(&sb, stats)
+
+	// Writing style
+	sb.WriteString("## Стиль письма\n\n")
+	writeStyleSection(&sb, stats, mask)
+
 	// Most active time window
 	sb.WriteString("## Самый активный период\n\n")
 	sb.WriteString(fmt.Sprintf("**%02d:00 — %02d:00** — %d сообщений\n\n",
@@ -177,11 +1088,15 @@ func generateYearReport(chatName, chatType string, stats *analyzer.YearStats) st
 	}
 	sb.WriteString("\n")
 
+	// Bursts
+	sb.WriteString("## Всплески активности\n\n")
+	writeBurstSection(&sb, all.BurstsIn(stats.Period), 5, mask)
+
 	return sb.String()
 }
 
 // generateOverallReport creates a summary report across all years
-func generateOverallReport(stats *analyzer.Stats) string {
+func generateOverallReport(stats *analyzer.Stats, mask bool) string {
 	var sb strings.Builder
 
 	sb.WriteString("# Общий отчет по чату\n\n")
@@ -196,7 +1111,8 @@ func generateOverallReport(stats *analyzer.Stats) string {
 	sb.WriteString(fmt.Sprintf("- **Всего сообщений:** %d\n", stats.Overall.TotalMessages))
 	sb.WriteString(fmt.Sprintf("- **Всего ответов:** %d\n", stats.Overall.RepliesCount))
 	sb.WriteString(fmt.Sprintf("- **Всего пересланных:** %d\n", stats.Overall.ForwardedCount))
-	sb.WriteString(fmt.Sprintf("- **Средняя длина сообщения:** %.1f символов\n\n", stats.Overall.AvgMessageLength))
+	sb.WriteString(fmt.Sprintf("- **Средняя длина сообщения:** %.1f символов\n", stats.Overall.AvgMessageLength))
+	sb.WriteString(fmt.Sprintf("- **Стоп-слова:** %s\n\n", formatStopWords(stats.StopWords)))
 
 	// Yearly summary
 	sb.WriteString("## Статистика по годам\n\n")
@@ -254,6 +1170,43 @@ func generateOverallReport(stats *analyzer.Stats) string {
 		sb.WriteString("\n")
 	}
 
+	// Who starts conversations overall
+	sb.WriteString("## Кто пишет первым (всего)\n\n")
+	writeInitiatorSection(&sb, &stats.Overall, stats.InitiatorSilence)
+
+	// Runs of messages overall
+	sb.WriteString("## Серии сообщений (всего)\n\n")
+	writeRunSection(&sb, &stats.Overall)
+
+	// Questions overall
+	sb.WriteString("## Вопросы (всего)\n\n")
+	writeQuestionSection(&sb, &stats.Overall)
+
+	// Laughter overall
+	sb.WriteString("## Смех (всего)\n\n")
+	writeLaughterSection(&sb, &stats.Overall)
+
+	// Profanity overall
+	sb.WriteString("## Мат (всего)\n\n")
+	writeProfanitySection(&sb, &stats.Overall, mask)
+	sb.WriteString("### Мат по годам\n\n")
+	sb.WriteString("| Год | Матерных слов | На 100 сообщений | Кто больше всех |\n")
+	sb.WriteString("|-----|---------------|------------------|-----------------|\n")
+	for _, year := range stats.GetSortedYears() {
+		ys := stats.ByYear[year]
+		total := ys.ProfanityTotal()
+		sb.WriteString(fmt.Sprintf("| %d | %d | %.1f | %s |\n", year, total, perMessage(total, ys.TotalMessages)*100, formatUserCounts(analyzer.GetSortedUsers(ys.ProfanityCountByUser), 3)))
+	}
+	sb.WriteString("\n")
+
+	// Message lengths overall
+	sb.WriteString("## Длина сообщений (всего)\n\n")
+	writeLengthSection(&sb, &stats.Overall, mask)
+
+	// Vocabulary overall
+	sb.WriteString("## Словарный запас (всего)\n\n")
+	writeVocabularySection(&sb, &stats.Overall, mask)
+
 	// Top 20 words by user (overall)
 	sb.WriteString("## Топ-20 популярных слов по участникам (всего)\n\n")
 
@@ -265,7 +1218,7 @@ func generateOverallReport(stats *analyzer.Stats) string {
 			sb.WriteString("| # | Слово | Количество |\n")
 			sb.WriteString("|---|-------|------------|\n")
 			for i, wc := range topWords {
-				sb.WriteString(fmt.Sprintf("| %d | %s | %d |\n", i+1, wc.Word, wc.Count))
+				sb.WriteString(fmt.Sprintf("| %d | %s | %d |\n", i+1, maskText(wc.DisplayWord(), mask), wc.Count))
 			}
 			sb.WriteString("\n")
 		}
@@ -285,13 +1238,75 @@ func generateOverallReport(stats *analyzer.Stats) string {
 				sb.WriteString("| # | Слово | Количество |\n")
 				sb.WriteString("|---|-------|------------|\n")
 				for i, wc := range topWords {
-					sb.WriteString(fmt.Sprintf("| %d | %s | %d |\n", i+1, wc.Word, wc.Count))
+					sb.WriteString(fmt.Sprintf("| %d | %s | %d |\n", i+1, maskText(wc.DisplayWord(), mask), wc.Count))
 				}
 				sb.WriteString("\n")
 			}
 		}
 	}
 
+	// Phrases overall
+	sb.WriteString("## Популярные фразы (всего)\n\n")
+	writePhraseSection(&sb, &stats.Overall, mask)
+
+	// Topics
+	if len(stats.Topics) > 0 {
+		sb.WriteString("## Темы переписки\n\n")
+		writeTopicSection(&sb, stats, sortMonths(stats.Overall.MonthlyActivity), mask)
+	}
+
+	// Tracked terms
+	if len(stats.Terms) > 0 {
+		sb.WriteString("## Отслеживаемые слова\n\n")
+		writeTrackingSection(&sb, stats.Terms, &stats.Overall)
+	}
+
+	// Signature words overall and themes of each year
+	sb.WriteString("## Характерные слова (всего)\n\n")
+	writeUserSignatures(&sb, &stats.Overall, mask)
+
+	sb.WriteString("### Темы по годам\n\n")
+	sb.WriteString("| Год | Характерные слова |\n")
+	sb.WriteString("|-----|-------------------|\n")
+	for _, year := range stats.GetSortedYears() {
+		sb.WriteString(fmt.Sprintf("| %d | %s |\n", year, joinSignatureWords(stats.ByYear[year].SignatureWords, 10, mask)))
+	}
+	sb.WriteString("\n")
+
+	// Emoji overall and by year
+	sb.WriteString("## Эмодзи (всего)\n\n")
+	writeEmojiSection(&sb, &stats.Overall)
+
+	sb.WriteString("### Эмодзи по годам\n\n")
+	sb.WriteString("| Год | Эмодзи | На сообщение | Популярные |\n")
+	sb.WriteString("|-----|--------|--------------|------------|\n")
+	for _, year := range stats.GetSortedYears() {
+		ys := stats.ByYear[year]
+		sb.WriteString(fmt.Sprintf("| %d | %d | %.2f | %s |\n",
+			year, ys.EmojiCount, perMessage(ys.EmojiCount, ys.TotalMessages), joinWordCounts(ys.TopEmoji, 5, false)))
+	}
+	sb.WriteString("\n")
+
+	// Sentiment overall
+	sb.WriteString("## Настроение (всего)\n\n")
+	writeSentimentSection(&sb, &stats.Overall)
+
+	// Language mix overall and by year
+	sb.WriteString("## Языки сообщений (всего)\n\n")
+	writeLanguageSection(&sb, &stats.Overall)
+
+	sb.WriteString("### Языки по годам\n\n")
+	sb.WriteString("| Год | Языки |\n")
+	sb.WriteString("|-----|-------|\n")
+	for _, year := range stats.GetSortedYears() {
+		sb.WriteString(fmt.Sprintf("| %d | %s |\n", year, formatLanguageMix(stats.ByYear[year].MessagesByLanguage)))
+	}
+	sb.WriteString("\n")
+
+	// Writing style overall
+	sb.WriteString("## Стиль письма (всего)\n\n")
+	writeStyleSection(&sb, &stats.Overall, mask)
+
 	// Most active time window overall
 	sb.WriteString("## Самый активный период (общий)\n\n")
 	sb.WriteString(fmt.Sprintf("**%02d:00 — %02d:00** — %d сообщений\n\n",
@@ -337,11 +1352,20 @@ func generateOverallReport(stats *analyzer.Stats) string {
 	}
 	sb.WriteString("\n")
 
+	// Duplicates
+	sb.WriteString("## Повторяющиеся сообщения\n\n")
+	writeDuplicateSection(&sb, stats, 10, mask)
+
+	// Bursts
+	sb.WriteString("## Всплески активности\n\n")
+	writeBurstSection(&sb, stats.Bursts, 10, mask)
+
 	return sb.String()
 }
 
-// PrintConsoleStats prints statistics to console
-func PrintConsoleStats(stats *analyzer.Stats) {
+// PrintConsoleStats prints statistics to console;
+// mask hides obscene words in word lists and message excerpts
+func PrintConsoleStats(stats *analyzer.Stats, mask bool) {
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	fmt.Printf("АНАЛИЗ ЧАТА: %s\n", stats.ChatName)
 	fmt.Println(strings.Repeat("=", 60))
@@ -379,7 +1403,7 @@ func PrintConsoleStats(stats *analyzer.Stats) {
 					if i >= 10 {
 						break // Show only top 10 in console
 					}
-					fmt.Printf("    %2d. %s (%d)\n", i+1, wc.Word, wc.Count)
+					fmt.Printf("    %2d. %s (%d)\n", i+1, maskText(wc.DisplayWord(), mask), wc.Count)
 				}
 			}
 		}
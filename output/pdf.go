@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"telegram_message_analyzer/analyzer"
@@ -29,6 +30,7 @@ const (
 type PDFGenerator struct {
 	pdf      *gopdf.GoPdf
 	fontPath string
+	mask     bool // hide obscene words in word lists and message excerpts
 	y        float64
 }
 
@@ -58,8 +60,9 @@ func findFont() string {
 	return ""
 }
 
-// GeneratePDFReports creates PDF reports for each year
-func GeneratePDFReports(stats *analyzer.Stats, outputDir string) error {
+// GeneratePDFReports creates PDF reports for each period;
+// mask hides obscene words in word lists and message excerpts
+func GeneratePDFReports(stats *analyzer.Stats, outputDir string, mask bool) error {
 	fontPath := findFont()
 	if fontPath == "" {
 		return fmt.Errorf("не найден TTF шрифт с поддержкой кириллицы")
@@ -70,14 +73,14 @@ func GeneratePDFReports(stats *analyzer.Stats, outputDir string) error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Generate report for each year
-	for _, year := range stats.GetSortedYears() {
-		yearStats := stats.ByYear[year]
-		filename := filepath.Join(outputDir, fmt.Sprintf("%d_report.pdf", year))
+	// Generate report for each period
+	for _, key := range stats.GetSortedPeriods() {
+		periodStats := stats.ByPeriod[key]
+		filename := filepath.Join(outputDir, periodFilename(periodStats.Period)+".pdf")
 
-		gen := &PDFGenerator{fontPath: fontPath}
-		if err := gen.generateYearPDF(stats.ChatName, stats.ChatType, yearStats, filename); err != nil {
-			return fmt.Errorf("failed to generate PDF for %d: %w", year, err)
+		gen := &PDFGenerator{fontPath: fontPath, mask: mask}
+		if err := gen.generateYearPDF(stats, periodStats, filename); err != nil {
+			return fmt.Errorf("failed to generate PDF for %s: %w", key, err)
 		}
 
 		fmt.Printf("Создан PDF отчет: %s\n", filename)
@@ -85,7 +88,7 @@ func GeneratePDFReports(stats *analyzer.Stats, outputDir string) error {
 
 	// Generate overall report
 	overallFilename := filepath.Join(outputDir, "overall_report.pdf")
-	gen := &PDFGenerator{fontPath: fontPath}
+	gen := &PDFGenerator{fontPath: fontPath, mask: mask}
 	if err := gen.generateOverallPDF(stats, overallFilename); err != nil {
 		return fmt.Errorf("failed to generate overall PDF: %w", err)
 	}
@@ -173,19 +176,466 @@ func (g *PDFGenerator) addSpace(height float64) {
 	g.y += height
 }
 
-func (g *PDFGenerator) generateYearPDF(chatName, chatType string, stats *analyzer.YearStats, filename string) error {
+func (g *PDFGenerator) writePhraseTable(phrases []analyzer.PhraseCount) {
+	phraseWidths := []float64{30, 250, 60, 60}
+	for i, pc := range phrases {
+		g.writeTableRow([]string{
+			fmt.Sprintf("%d.", i+1),
+			maskText(pc.DisplayPhrase(), g.mask),
+			fmt.Sprintf("%d", pc.Count),
+			fmt.Sprintf("%.1f", pc.Score),
+		}, phraseWidths)
+	}
+	g.addSpace(5)
+}
+
+func (g *PDFGenerator) writePhraseSection(stats *analyzer.YearStats) {
+	g.writeHeader("Популярные фразы")
+	if len(stats.TopBigrams) > 0 {
+		g.writeSubHeader("Сочетания из двух слов")
+		g.writePhraseTable(stats.TopBigrams)
+	}
+	if len(stats.TopTrigrams) > 0 {
+		g.writeSubHeader("Сочетания из трех слов")
+		g.writePhraseTable(stats.TopTrigrams)
+	}
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		if phrases := stats.TopPhrasesByUser[user.Name]; len(phrases) > 0 {
+			g.writeSubHeader(user.Name)
+			g.writePhraseTable(phrases)
+		}
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeUserSignatures(stats *analyzer.YearStats) {
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		if words := stats.SignatureWordsByUser[user.Name]; len(words) > 0 {
+			g.writeLine(fmt.Sprintf("%s: %s", user.Name, joinSignatureWords(words, 10, g.mask)))
+		}
+	}
+	g.addSpace(10)
+}
+
+// emojiLabel spells out emoji code points, since PDF fonts usually lack emoji glyphs
+func emojiLabel(emoji string) string {
+	parts := make([]string, 0, 2)
+	for _, r := range emoji {
+		if r == '\ufe0f' || r == '\u200d' {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("U+%04X", r))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (g *PDFGenerator) writeEmojiSection(stats *analyzer.YearStats) {
+	g.writeHeader("Эмодзи")
+	g.writeLine(fmt.Sprintf("Всего эмодзи: %d, на сообщение: %.2f",
+		stats.EmojiCount, perMessage(stats.EmojiCount, stats.TotalMessages)))
+	emojiWidths := []float64{30, 200, 80}
+	for i, wc := range stats.TopEmoji {
+		if i >= 10 {
+			break
+		}
+		g.writeTableRow([]string{
+			fmt.Sprintf("%d.", i+1),
+			wc.Word + " " + emojiLabel(wc.Word),
+			fmt.Sprintf("%d", wc.Count),
+		}, emojiWidths)
+	}
+	g.addSpace(5)
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		g.writeLine(fmt.Sprintf("%s: %d эмодзи, %.2f на сообщение",
+			user.Name,
+			stats.EmojiCountByUser[user.Name],
+			perMessage(stats.EmojiCountByUser[user.Name], user.Count)))
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeSentimentSection(stats *analyzer.YearStats) {
+	s := stats.Sentiment
+	g.writeHeader("Настроение")
+	g.writeLine(fmt.Sprintf("Среднее настроение: %+.3f (от -1 до 1), позитивных: %d, негативных: %d",
+		s.Average(), s.Positive, s.Negative))
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		g.writeLine(fmt.Sprintf("%s: %+.3f", user.Name, stats.SentimentByUser[user.Name].Average()))
+	}
+	g.addSpace(5)
+
+	g.writeSubHeader("По месяцам")
+	for _, m := range sortMonths(stats.MonthlyActivity) {
+		score := stats.SentimentByMonth[m.key].Average()
+		g.writeLine(fmt.Sprintf("%s: %+.3f %s", formatMonth(m.key), score, sentimentBar(score)))
+	}
+	g.addSpace(5)
+
+	for _, block := range []struct {
+		title string
+		days  []analyzer.DaySentiment
+	}{
+		{"Самые позитивные дни", stats.MostPositiveDays},
+		{"Самые негативные дни", stats.MostNegativeDays},
+	} {
+		if len(block.days) == 0 {
+			continue
+		}
+		g.writeSubHeader(block.title)
+		for _, d := range block.days {
+			g.writeLine(fmt.Sprintf("%s: %+.3f (%d сообщений)", d.Date.Format("02.01.2006"), d.Score, d.Messages))
+		}
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeTopicSection(stats *analyzer.Stats, months []monthEntry) {
+	if len(stats.Topics) == 0 {
+		return
+	}
+	g.writeHeader("Темы переписки")
+	for _, topic := range stats.Topics {
+		g.writeLine(fmt.Sprintf("%d (%.1f%%): %s", topic.ID, topic.Share*100, topicLabel(topic, 8, g.mask)))
+	}
+	g.addSpace(5)
+
+	g.writeSubHeader("Главная тема по месяцам")
+	for _, m := range months {
+		shares := stats.TopicsByMonth[m.key]
+		if shares == nil {
+			continue
+		}
+		top := 0
+		for t, share := range shares {
+			if share > shares[top] {
+				top = t
+			}
+		}
+		g.writeLine(fmt.Sprintf("%s: тема %d (%.0f%%) — %s",
+			formatMonth(m.key), top+1, shares[top]*100, topicLabel(stats.Topics[top], 3, g.mask)))
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeTrackingSection(terms []analyzer.TermStats, stats *analyzer.YearStats) {
+	if len(terms) == 0 {
+		return
+	}
+	months := sortMonths(stats.MonthlyActivity)
+	g.writeHeader("Отслеживаемые слова")
+	for _, term := range terms {
+		total, max := termMonthMax(term, months)
+		g.writeSubHeader(fmt.Sprintf("%s — %d сообщений", term.Name, total))
+		g.writeLine(fmt.Sprintf("Шаблон: %s", term.Pattern))
+		if total == 0 {
+			continue
+		}
+		for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+			count := 0
+			for _, m := range months {
+				count += term.ByUserMonth[user.Name][m.key]
+			}
+			g.writeLine(fmt.Sprintf("%s: %d", user.Name, count))
+		}
+		for _, m := range months {
+			count := term.ByMonth[m.key]
+			g.writeLine(fmt.Sprintf("%s: %d %s", formatMonth(m.key), count, trendBar(count, max)))
+		}
+		g.addSpace(5)
+	}
+	g.addSpace(5)
+}
+
+func (g *PDFGenerator) writeLengthSection(stats *analyzer.YearStats) {
+	l := stats.Lengths
+	g.writeHeader("Длина сообщений")
+	g.writeLine(fmt.Sprintf("Медиана: %d, 90-й перцентиль: %d, 99-й перцентиль: %d, максимум: %d символов",
+		l.Median, l.P90, l.P99, l.Max))
+	g.addSpace(5)
+
+	maxCount := 0
+	for _, b := range stats.LengthHistogram {
+		maxCount = max(maxCount, b.Count)
+	}
+	bucketWidths := []float64{80, 60, 60}
+	for _, b := range stats.LengthHistogram {
+		g.writeTableRow([]string{
+			formatBucket(b),
+			fmt.Sprintf("%d", b.Count),
+			fmt.Sprintf("%.1f%%", perMessage(b.Count, stats.TotalMessages)*100),
+			trendBar(b.Count, maxCount),
+		}, bucketWidths)
+	}
+	g.addSpace(5)
+
+	mainUsers := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
+	g.writeSubHeader("По участникам")
+	for _, user := range mainUsers {
+		ul := stats.LengthsByUser[user.Name]
+		g.writeLine(fmt.Sprintf("%s: медиана %d, 90-й перцентиль %d, максимум %d, слов на сообщение %.1f",
+			user.Name, ul.Median, ul.P90, ul.Max, perMessage(stats.WordCountByUser[user.Name], user.Count)))
+	}
+	g.addSpace(5)
+
+	g.writeSubHeader("Самые длинные сообщения")
+	for _, user := range mainUsers {
+		if m, ok := stats.LongestMessageByUser[user.Name]; ok {
+			g.writeLine(fmt.Sprintf("%s (%s, %d символов): %s",
+				user.Name, m.Date.Format("02.01.2006"), m.Length, truncateText(m.Text, 60, g.mask)))
+		}
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeVocabularySection(stats *analyzer.YearStats) {
+	g.writeHeader("Словарный запас")
+	widths := []float64{150, 70, 80, 60, 60}
+	g.writeTableRow([]string{"Участник", "Слов", "Разных слов", "TTR", "MTLD"}, widths)
+	row := func(name string, v analyzer.VocabularyStats) {
+		g.writeTableRow([]string{
+			name,
+			fmt.Sprintf("%d", v.Tokens),
+			fmt.Sprintf("%d", v.Types),
+			fmt.Sprintf("%.3f", v.TTR),
+			formatMTLD(v),
+		}, widths)
+	}
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		row(user.Name, stats.VocabularyByUser[user.Name])
+	}
+	row("Все", stats.Vocabulary)
+	g.addSpace(5)
+
+	if len(stats.NewWords) > 0 {
+		g.writeSubHeader("Новые слова")
+		for _, w := range stats.NewWords {
+			g.writeLine(fmt.Sprintf("%s (%d) — %s, %s", maskText(w.DisplayWord(), g.mask), w.Count, w.User, w.Date.Format("02.01.2006")))
+		}
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeBurstSection(bursts []analyzer.Burst, n int) {
+	g.addSpace(10)
+	g.writeHeader("Всплески активности")
+	if len(bursts) == 0 {
+		g.writeLine("Необычных всплесков активности не найдено")
+		return
+	}
+	for i, b := range bursts {
+		if i >= n {
+			break
+		}
+		g.writeSubHeader(fmt.Sprintf("%s: %d сообщений (обычно %.0f)", formatBurstDates(b), b.Messages, b.Expected))
+		g.writeLine("Участники: " + formatUserCounts(b.Users, 3))
+		if len(b.Words) > 0 {
+			g.writeLine("Слова: " + joinSignatureWords(b.Words, 8, g.mask))
+		}
+	}
+}
+
+func (g *PDFGenerator) writeInitiatorSection(stats *analyzer.YearStats, silence time.Duration) {
+	g.writeHeader("Кто пишет первым")
+	g.writeLine(fmt.Sprintf("Разговоров после тишины дольше %s: %d", formatSilence(silence), stats.Conversations))
+	if stats.Conversations == 0 {
+		g.addSpace(10)
+		return
+	}
+	g.addSpace(5)
+
+	users := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
+	widths := []float64{150, 70, 70, 100, 110}
+	g.writeTableRow([]string{"Участник", "Начал", "Доля", "Время", "День"}, widths)
+	for _, user := range users {
+		in := stats.InitiationsByUser[user.Name]
+		if in == nil {
+			g.writeTableRow([]string{user.Name, "0", "0.0%", "—", "—"}, widths)
+			continue
+		}
+		hour := in.FavoriteHour()
+		g.writeTableRow([]string{
+			user.Name,
+			fmt.Sprintf("%d", in.Count),
+			fmt.Sprintf("%.1f%%", stats.InitiationShare(user.Name)*100),
+			fmt.Sprintf("%02d:00-%02d:00", hour, hour+1),
+			russianWeekdays[in.FavoriteWeekday()],
+		}, widths)
+	}
+	g.addSpace(5)
+
+	g.writeSubHeader("По месяцам")
+	for _, m := range sortMonths(stats.MonthlyActivity) {
+		total := stats.InitiationsInMonth(m.key)
+		if total == 0 {
+			continue
+		}
+		parts := make([]string, 0, len(users))
+		for _, user := range users {
+			if in := stats.InitiationsByUser[user.Name]; in != nil && in.Months[m.key] > 0 {
+				parts = append(parts, fmt.Sprintf("%s %s", user.Name, formatInitiations(in.Months[m.key], total)))
+			}
+		}
+		g.writeLine(fmt.Sprintf("%s: %s", formatMonth(m.key), strings.Join(parts, ", ")))
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeRunSection(stats *analyzer.YearStats) {
+	g.writeHeader("Серии сообщений")
+	g.writeLine(fmt.Sprintf("Серий: %d, сообщений в серии в среднем: %.2f",
+		stats.Runs, perMessage(stats.RunMessages, stats.Runs)))
+	if stats.LongestRun.Messages > 0 {
+		g.writeLine("Самый длинный монолог: " + formatRun(stats.LongestRun))
+	}
+	g.addSpace(5)
+
+	widths := []float64{150, 60, 90, 90, 90}
+	g.writeTableRow([]string{"Участник", "Серий", "В серии", "Дописывает", "Рекорд"}, widths)
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		g.writeTableRow([]string{
+			user.Name,
+			fmt.Sprintf("%d", stats.RunsByUser[user.Name]),
+			fmt.Sprintf("%.2f", stats.AvgRunLength(user.Name)),
+			fmt.Sprintf("%.1f%%", stats.FollowUpRate(user.Name)*100),
+			fmt.Sprintf("%d", stats.LongestRunByUser[user.Name].Messages),
+		}, widths)
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeQuestionSection(stats *analyzer.YearStats) {
+	questions, answered := stats.QuestionTotals()
+	g.writeHeader("Вопросы")
+	g.writeLine(fmt.Sprintf("Вопросов: %d (%.1f%% сообщений), с ответом: %d (%.1f%%)",
+		questions, perMessage(questions, stats.TotalMessages)*100, answered, perMessage(answered, questions)*100))
+	g.addSpace(5)
+
+	widths := []float64{150, 70, 70, 70, 120}
+	g.writeTableRow([]string{"Участник", "Вопросов", "С ответом", "Без ответа", "Время ответа"}, widths)
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		asked, got := stats.QuestionsByUser[user.Name], stats.AnsweredByUser[user.Name]
+		g.writeTableRow([]string{
+			user.Name,
+			fmt.Sprintf("%d", asked),
+			fmt.Sprintf("%d", got),
+			fmt.Sprintf("%d", asked-got),
+			formatAnswerTime(stats, user.Name),
+		}, widths)
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeLaughterSection(stats *analyzer.YearStats) {
+	g.writeHeader("Смех")
+	widths := []float64{150, 60, 60, 60, 150}
+	g.writeTableRow([]string{"Участник", "Смеется", "Доля", "Смешит", "Смеется над"}, widths)
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		laughs := stats.LaughsByUser[user.Name]
+		g.writeTableRow([]string{
+			user.Name,
+			fmt.Sprintf("%d", laughs),
+			fmt.Sprintf("%.1f%%", perMessage(laughs, user.Count)*100),
+			fmt.Sprintf("%d", stats.LaughTriggersByUser[user.Name]),
+			formatFunniest(stats, user.Name),
+		}, widths)
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeProfanitySection(stats *analyzer.YearStats) {
+	total := stats.ProfanityTotal()
+	g.writeHeader("Мат")
+	g.writeLine(fmt.Sprintf("Матерных слов: %d (%.1f на 100 сообщений)", total, perMessage(total, stats.TotalMessages)*100))
+	if len(stats.TopProfanity) > 0 {
+		g.writeLine("Самые частые: " + joinWordCounts(stats.TopProfanity, 10, g.mask))
+	}
+	g.addSpace(5)
+
+	widths := []float64{150, 70, 70, 200}
+	g.writeTableRow([]string{"Участник", "Слов", "На 100", "Частые слова"}, widths)
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		count := stats.ProfanityCountByUser[user.Name]
+		g.writeTableRow([]string{
+			user.Name,
+			fmt.Sprintf("%d", count),
+			fmt.Sprintf("%.1f", perMessage(count, user.Count)*100),
+			joinWordCounts(stats.TopProfanityByUser[user.Name], 3, g.mask),
+		}, widths)
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeDuplicateSection(stats *analyzer.Stats, n int) {
+	g.addSpace(10)
+	g.writeHeader("Повторяющиеся сообщения")
+	if len(stats.Duplicates) == 0 {
+		g.writeLine("Повторяющихся сообщений не найдено")
+		return
+	}
+	g.writeLine(fmt.Sprintf("Групп повторов: %d, повторных сообщений: %d (%.1f%%)", len(stats.Duplicates),
+		stats.DuplicateMessages(), perMessage(stats.DuplicateMessages(), stats.Overall.TotalMessages)*100))
+	if stats.DuplicatesExcluded {
+		g.writeLine("Повторы не учитываются в подсчете слов")
+	}
+	g.addSpace(5)
+	for i, d := range stats.Duplicates {
+		if i >= n {
+			break
+		}
+		g.writeLine(fmt.Sprintf("%d раз (%s — %s), %s: %s", d.Messages,
+			d.First.Format("02.01.2006"), d.Last.Format("02.01.2006"), formatUserCounts(d.Senders, 3), truncateText(d.Text, 50, g.mask)))
+	}
+}
+
+func (g *PDFGenerator) writeStyleSection(stats *analyzer.YearStats) {
+	users := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
+	if len(users) > 4 {
+		users = users[:4]
+	}
+	g.writeHeader("Стиль письма")
+	first := 170.0
+	widths := []float64{first}
+	names := []string{"Показатель"}
+	for _, user := range users {
+		widths = append(widths, (pageWidth-marginLeft-marginRight-first)/float64(len(users)))
+		names = append(names, user.Name)
+	}
+	g.writeTableRow(names, widths)
+	for _, row := range styleRows(stats, users) {
+		g.writeTableRow(row, widths)
+	}
+	g.addSpace(5)
+
+	g.writeSubHeader("Возможные опечатки")
+	for _, user := range users {
+		g.writeLine(fmt.Sprintf("%s: %s", user.Name, formatTypos(stats.TyposByUser[user.Name], 4, g.mask)))
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) writeLanguageSection(stats *analyzer.YearStats) {
+	g.writeHeader("Языки сообщений")
+	g.writeLine(formatLanguageMix(stats.MessagesByLanguage))
+	g.addSpace(5)
+	for _, user := range analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages) {
+		g.writeLine(fmt.Sprintf("%s: %s", user.Name, formatLanguageMix(stats.LanguagesByUser[user.Name])))
+	}
+	g.addSpace(10)
+}
+
+func (g *PDFGenerator) generateYearPDF(all *analyzer.Stats, stats *analyzer.YearStats, filename string) error {
 	if err := g.initPDF(); err != nil {
 		return err
 	}
 
 	// Title
-	g.writeTitle(fmt.Sprintf("Отчет по чату за %d год", stats.Year))
+	g.writeTitle(fmt.Sprintf("Отчет по чату за %s", periodTitle(stats.Period)))
 	g.addSpace(10)
 
 	// Metadata
 	g.writeHeader("Метаданные чата")
-	g.writeLine(fmt.Sprintf("Название чата: %s", chatName))
-	g.writeLine(fmt.Sprintf("Тип: %s", chatType))
+	g.writeLine(fmt.Sprintf("Название чата: %s", all.ChatName))
+	g.writeLine(fmt.Sprintf("Тип: %s", all.ChatType))
 	g.writeLine(fmt.Sprintf("Период: %s — %s",
 		stats.FirstMessage.Format("02.01.2006"),
 		stats.LastMessage.Format("02.01.2006")))
@@ -193,6 +643,7 @@ func (g *PDFGenerator) generateYearPDF(chatName, chatType string, stats *analyze
 	g.writeLine(fmt.Sprintf("Ответов: %d", stats.RepliesCount))
 	g.writeLine(fmt.Sprintf("Пересланных: %d", stats.ForwardedCount))
 	g.writeLine(fmt.Sprintf("Средняя длина сообщения: %.1f символов", stats.AvgMessageLength))
+	g.writeLine(fmt.Sprintf("Стоп-слова: %s", formatStopWords(all.StopWords)))
 	g.addSpace(10)
 
 	// Messages by user
@@ -218,6 +669,27 @@ func (g *PDFGenerator) generateYearPDF(chatName, chatType string, stats *analyze
 	}
 	g.addSpace(10)
 
+	// Who starts conversations
+	g.writeInitiatorSection(stats, all.InitiatorSilence)
+
+	// Runs of messages
+	g.writeRunSection(stats)
+
+	// Questions
+	g.writeQuestionSection(stats)
+
+	// Laughter
+	g.writeLaughterSection(stats)
+
+	// Profanity
+	g.writeProfanitySection(stats)
+
+	// Message lengths
+	g.writeLengthSection(stats)
+
+	// Vocabulary
+	g.writeVocabularySection(stats)
+
 	// Top words by user
 	g.writeHeader("Топ-20 слов по участникам")
 	mainUsers := analyzer.GetMainUsers(stats.MessagesByUser, stats.TotalMessages)
@@ -229,7 +701,7 @@ func (g *PDFGenerator) generateYearPDF(chatName, chatType string, stats *analyze
 			for i, wc := range topWords {
 				g.writeTableRow([]string{
 					fmt.Sprintf("%d.", i+1),
-					wc.Word,
+					maskText(wc.DisplayWord(), g.mask),
 					fmt.Sprintf("%d", wc.Count),
 				}, wordWidths)
 			}
@@ -237,6 +709,34 @@ func (g *PDFGenerator) generateYearPDF(chatName, chatType string, stats *analyze
 		}
 	}
 
+	// Phrases
+	g.writePhraseSection(stats)
+
+	// Topics
+	g.writeTopicSection(all, sortMonths(stats.MonthlyActivity))
+	g.writeTrackingSection(all.Terms, stats)
+
+	// Signature words
+	g.writeHeader("Характерные слова")
+	if len(stats.SignatureWords) > 0 {
+		g.writeSubHeader(periodSignatureTitle(stats.Period))
+		g.writeLine(joinSignatureWords(stats.SignatureWords, 10, g.mask))
+		g.addSpace(5)
+	}
+	g.writeUserSignatures(stats)
+
+	// Emoji
+	g.writeEmojiSection(stats)
+
+	// Sentiment
+	g.writeSentimentSection(stats)
+
+	// Language mix
+	g.writeLanguageSection(stats)
+
+	// Writing style
+	g.writeStyleSection(stats)
+
 	// Time activity
 	g.writeHeader("Активность по времени")
 	g.writeLine(fmt.Sprintf("Самый активный период: %02d:00-%02d:00 (%d сообщений)",
@@ -251,6 +751,9 @@ func (g *PDFGenerator) generateYearPDF(chatName, chatType string, stats *analyze
 			stats.MostActiveMonth.Count))
 	}
 
+	// Bursts
+	g.writeBurstSection(all.BurstsIn(stats.Period), 5)
+
 	return g.pdf.WritePdf(filename)
 }
 
@@ -274,6 +777,7 @@ func (g *PDFGenerator) generateOverallPDF(stats *analyzer.Stats, filename string
 	g.writeLine(fmt.Sprintf("Всего ответов: %d", stats.Overall.RepliesCount))
 	g.writeLine(fmt.Sprintf("Всего пересланных: %d", stats.Overall.ForwardedCount))
 	g.writeLine(fmt.Sprintf("Средняя длина сообщения: %.1f символов", stats.Overall.AvgMessageLength))
+	g.writeLine(fmt.Sprintf("Стоп-слова: %s", formatStopWords(stats.StopWords)))
 	g.addSpace(10)
 
 	// Yearly summary
@@ -315,6 +819,27 @@ func (g *PDFGenerator) generateOverallPDF(stats *analyzer.Stats, filename string
 	}
 	g.addSpace(10)
 
+	// Who starts conversations overall
+	g.writeInitiatorSection(&stats.Overall, stats.InitiatorSilence)
+
+	// Runs of messages overall
+	g.writeRunSection(&stats.Overall)
+
+	// Questions overall
+	g.writeQuestionSection(&stats.Overall)
+
+	// Laughter overall
+	g.writeLaughterSection(&stats.Overall)
+
+	// Profanity overall
+	g.writeProfanitySection(&stats.Overall)
+
+	// Message lengths overall
+	g.writeLengthSection(&stats.Overall)
+
+	// Vocabulary overall
+	g.writeVocabularySection(&stats.Overall)
+
 	// Top words by user (overall)
 	g.writeHeader("Топ-20 слов по участникам (всего)")
 	mainUsers := analyzer.GetMainUsers(stats.Overall.MessagesByUser, stats.Overall.TotalMessages)
@@ -326,7 +851,7 @@ func (g *PDFGenerator) generateOverallPDF(stats *analyzer.Stats, filename string
 			for i, wc := range topWords {
 				g.writeTableRow([]string{
 					fmt.Sprintf("%d.", i+1),
-					wc.Word,
+					maskText(wc.DisplayWord(), g.mask),
 					fmt.Sprintf("%d", wc.Count),
 				}, wordWidths)
 			}
@@ -334,6 +859,47 @@ func (g *PDFGenerator) generateOverallPDF(stats *analyzer.Stats, filename string
 		}
 	}
 
+	// Phrases
+	g.writePhraseSection(&stats.Overall)
+
+	// Topics
+	g.writeTopicSection(stats, sortMonths(stats.Overall.MonthlyActivity))
+	g.writeTrackingSection(stats.Terms, &stats.Overall)
+
+	// Signature words
+	g.writeHeader("Характерные слова")
+	g.writeUserSignatures(&stats.Overall)
+	g.writeSubHeader("Темы по годам")
+	for _, year := range stats.GetSortedYears() {
+		g.writeLine(fmt.Sprintf("%d: %s", year, joinSignatureWords(stats.ByYear[year].SignatureWords, 10, g.mask)))
+	}
+	g.addSpace(10)
+
+	// Sentiment
+	g.writeSentimentSection(&stats.Overall)
+
+	// Emoji
+	g.writeEmojiSection(&stats.Overall)
+	g.writeSubHeader("По годам")
+	for _, year := range stats.GetSortedYears() {
+		ys := stats.ByYear[year]
+		g.writeLine(fmt.Sprintf("%d: %d эмодзи, %.2f на сообщение",
+			year, ys.EmojiCount, perMessage(ys.EmojiCount, ys.TotalMessages)))
+	}
+	g.addSpace(10)
+
+	// Language mix
+	g.writeLanguageSection(&stats.Overall)
+
+	g.writeSubHeader("По годам")
+	for _, year := range stats.GetSortedYears() {
+		g.writeLine(fmt.Sprintf("%d: %s", year, formatLanguageMix(stats.ByYear[year].MessagesByLanguage)))
+	}
+	g.addSpace(10)
+
+	// Writing style overall
+	g.writeStyleSection(&stats.Overall)
+
 	// Time activity
 	g.writeHeader("Активность по времени")
 	g.writeLine(fmt.Sprintf("Самый активный период: %02d:00-%02d:00 (%d сообщений)",
@@ -373,6 +939,12 @@ func (g *PDFGenerator) generateOverallPDF(stats *analyzer.Stats, filename string
 		}
 	}
 
+	// Duplicates
+	g.writeDuplicateSection(stats, 10)
+
+	// Bursts
+	g.writeBurstSection(stats.Bursts, 10)
+
 	return g.pdf.WritePdf(filename)
 }
 
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"strings"
+	"time"
+)
+
+// Filter selects a part of an export by date and participants
+type Filter struct {
+	From         time.Time // first day to include, zero means no limit
+	To           time.Time // last day to include, zero means no limit
+	Users        []string  // only messages of these senders, empty means everyone
+	ExcludeUsers []string  // messages of these senders are dropped
+}
+
+// IsEmpty reports whether the filter keeps every message
+func (f Filter) IsEmpty() bool {
+	return f.From.IsZero() && f.To.IsZero() && len(f.Users) == 0 && len(f.ExcludeUsers) == 0
+}
+
+// Apply returns a new result with only the matching messages.
+// Sender names are compared case-insensitively.
+func (f Filter) Apply(result *ParseResult) *ParseResult {
+	include := nameSet(f.Users)
+	exclude := nameSet(f.ExcludeUsers)
+
+	// To is inclusive: keep everything before the start of the next day
+	var until time.Time
+	if !f.To.IsZero() {
+		until = time.Date(f.To.Year(), f.To.Month(), f.To.Day()+1, 0, 0, 0, 0, f.To.Location())
+	}
+
+	filtered := &ParseResult{
+		Metadata: ChatMetadata{Name: result.Metadata.Name, Type: result.Metadata.Type},
+		Messages: make([]Message, 0),
+	}
+	for _, msg := range result.Messages {
+		if !f.From.IsZero() && msg.Date.Before(f.From) {
+			continue
+		}
+		if !until.IsZero() && !msg.Date.Before(until) {
+			continue
+		}
+		name := strings.ToLower(msg.From)
+		if len(include) > 0 && !include[name] {
+			continue
+		}
+		if exclude[name] {
+			continue
+		}
+		filtered.Messages = append(filtered.Messages, msg)
+	}
+
+	if len(filtered.Messages) > 0 {
+		filtered.Metadata.FirstMessage = filtered.Messages[0].Date
+		filtered.Metadata.LastMessage = filtered.Messages[len(filtered.Messages)-1].Date
+		filtered.Metadata.TotalCount = len(filtered.Messages)
+	}
+	return filtered
+}
+
+// nameSet builds a lowercase lookup set of sender names
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	return set
+}
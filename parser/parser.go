@@ -14,6 +14,8 @@ import (
 
 // Message represents a single chat message
 type Message struct {
+	ID          int // message number in the export, 0 when unknown
+	ReplyTo     int // ID of the message this one replies to, 0 when unknown
 	ChatName    string
 	Date        time.Time
 	From        string
@@ -21,6 +23,15 @@ type Message struct {
 	Length      int
 	IsReply     bool
 	IsForwarded bool
+	Inferred    bool // sender is not in the export and could not be taken from the previous message
+}
+
+// DeletedAccount is the sender name Telegram exports use for removed accounts
+const DeletedAccount = "Deleted Account"
+
+// IsUnlabeled reports whether the real sender of a message is unknown
+func (m Message) IsUnlabeled() bool {
+	return m.Inferred || m.From == DeletedAccount
 }
 
 // ChatMetadata contains information about the chat
@@ -38,6 +49,9 @@ type ParseResult struct {
 	Messages []Message
 }
 
+// messageIDPattern matches "message123" and "#go_to_message123"
+var messageIDPattern = regexp.MustCompile(`message(\d+)$`)
+
 // ParseAllFiles parses all messages*.html files in the given directory
 func ParseAllFiles(dir string) (*ParseResult, error) {
 	files, err := filepath.Glob(filepath.Join(dir, "messages*.html"))
@@ -124,6 +138,11 @@ func parseFile(filename string) ([]Message, string, error) {
 			ChatName: chatName,
 		}
 
+		// Message IDs look like id="message123", reply links like href="#go_to_message123"
+		if id, ok := s.Attr("id"); ok {
+			msg.ID = parseMessageID(id)
+		}
+
 		// Parse date from title attribute (get the first one, not from forwarded)
 		dateEl := s.Find("> .body > .pull_right.date.details").First()
 		if dateEl.Length() == 0 {
@@ -146,13 +165,21 @@ func parseFile(filename string) ([]Message, string, error) {
 		// Format: "Name  DD.MM.YYYY HH:MM:SS" or "Name DD.MM.YYYY HH:MM:SS"
 		fromName = cleanForwardedName(fromName)
 
+		// Joined messages continue the previous message and share its sender; after a
+		// service message or at the start of a file the sender is only a guess
 		if fromName != "" {
 			lastFrom = fromName
+		} else if lastFrom == "" || !s.Prev().HasClass("default") {
+			msg.Inferred = true
 		}
 		msg.From = lastFrom
 
 		// Check if it's a reply
-		msg.IsReply = s.Find(".reply_to").Length() > 0
+		replyTo := s.Find(".reply_to").First()
+		msg.IsReply = replyTo.Length() > 0
+		if href, ok := replyTo.Find("a").Attr("href"); ok {
+			msg.ReplyTo = parseMessageID(href)
+		}
 
 		// Check if it's forwarded
 		msg.IsForwarded = s.Find(".forwarded").Length() > 0
@@ -178,6 +205,17 @@ func parseFile(filename string) ([]Message, string, error) {
 	return messages, chatName, nil
 }
 
+// parseMessageID extracts the message number from an element id or a reply link
+func parseMessageID(s string) int {
+	m := messageIDPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	var id int
+	fmt.Sscanf(m[1], "%d", &id)
+	return id
+}
+
 // cleanForwardedName removes date suffix from forwarded message sender names
 // Handles formats like "Name  DD.MM.YYYY HH:MM:SS" or "Name DD.MM.YYYY HH:MM:SS"
 func cleanForwardedName(name string) string {
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"telegram_message_analyzer/parser"
+	"telegram_message_analyzer/search"
+)
+
+// runSearch implements the "search" command: it indexes an export once and
+// answers the query from the command line or, without one, queries read from stdin
+func runSearch(args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	dataDir := fs.String("data", "path_to_tg", "Directory with exported Telegram HTML files")
+	contextLines := fs.Int("context", 2, "Number of messages to show before and after each match")
+	limit := fs.Int("limit", 50, "Maximum number of matches to print (0 prints all)")
+	fromDate := fs.String("from", "", "Only messages sent on or after this date (YYYY-MM-DD)")
+	toDate := fs.String("to", "", "Only messages sent on or before this date (YYYY-MM-DD)")
+	users := fs.String("users", "", "Comma-separated senders to include (default: everyone)")
+	excludeUsers := fs.String("exclude-users", "", "Comma-separated senders to exclude")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Использование: search [-data DIR] [-context N] [-limit N] [-from ДАТА] [-to ДАТА] [-users ИМЕНА] [запрос]")
+		fmt.Fprintln(os.Stderr, "Запрос: слова, \"фразы в кавычках\", author:имя, after:2023-01-01, before:2023-02-01, is:reply, is:forwarded")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	absDataDir, err := filepath.Abs(*dataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: не удалось получить путь к данным: %v\n", err)
+		os.Exit(1)
+	}
+
+	filter, err := buildFilter(*fromDate, *toDate, *users, *excludeUsers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		os.Exit(1)
+	}
+
+	result, err := parser.ParseAllFiles(absDataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка парсинга: %v\n", err)
+		os.Exit(1)
+	}
+	if !filter.IsEmpty() {
+		result = filter.Apply(result)
+	}
+	index := search.NewIndex(result)
+
+	if fs.NArg() > 0 {
+		if !printSearch(index, strings.Join(fs.Args(), " "), *contextLines, *limit) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Interactive mode: the index is built once and reused for every query
+	fmt.Println("Введите запрос (пустая строка — выход)")
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("🔍 ")
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			break
+		}
+		printSearch(index, line, *contextLines, *limit)
+	}
+}
+
+// printSearch runs a query and prints the matches with surrounding messages.
+// It returns false if the query is invalid.
+func printSearch(index *search.Index, text string, contextLines, limit int) bool {
+	query, err := search.ParseQuery(text)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка запроса: %v\n", err)
+		return false
+	}
+
+	matches := index.Search(query)
+	fmt.Printf("\nНайдено сообщений: %d\n", len(matches))
+	if limit > 0 && len(matches) > limit {
+		fmt.Printf("Показаны первые %d\n", limit)
+		matches = matches[:limit]
+	}
+
+	isMatch := make(map[int]bool, len(matches))
+	for _, i := range matches {
+		isMatch[i] = true
+	}
+
+	// Overlapping context windows are merged into one block
+	last := -1
+	for _, i := range matches {
+		start := max(i-contextLines, 0)
+		end := min(i+contextLines, index.Len()-1)
+		if start <= last {
+			start = last + 1
+		} else {
+			fmt.Println("\n──────────")
+		}
+		for j := start; j <= end; j++ {
+			prefix := "  "
+			if isMatch[j] {
+				prefix = "> "
+			}
+			msg := index.Message(j)
+			fmt.Printf("%s[%s] %s: %s\n", prefix, msg.Date.Format("02.01.2006 15:04"), msg.From, msg.Text)
+		}
+		last = end
+	}
+	fmt.Println()
+	return true
+}
@@ -0,0 +1,186 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"telegram_message_analyzer/parser"
+)
+
+// Index is an inverted index over the messages of a chat export
+type Index struct {
+	messages []parser.Message
+	postings map[string][]int // word -> ascending message positions
+}
+
+// NewIndex builds an index over parsed messages. Messages are expected in
+// chronological order, as returned by parser.ParseAllFiles.
+func NewIndex(result *parser.ParseResult) *Index {
+	idx := &Index{
+		messages: result.Messages,
+		postings: make(map[string][]int),
+	}
+	for i, msg := range result.Messages {
+		seen := make(map[string]bool)
+		for _, word := range tokenize(msg.Text) {
+			if !seen[word] {
+				seen[word] = true
+				idx.postings[word] = append(idx.postings[word], i)
+			}
+		}
+	}
+	return idx
+}
+
+// Len returns the number of indexed messages
+func (idx *Index) Len() int {
+	return len(idx.messages)
+}
+
+// Message returns the message at the given position
+func (idx *Index) Message(i int) parser.Message {
+	return idx.messages[i]
+}
+
+// Search returns the positions of all messages matching the query in chronological order
+func (idx *Index) Search(q Query) []int {
+	lo, hi := idx.dateRange(q)
+
+	var candidates []int
+	words := append([]string(nil), q.Words...)
+	for _, phrase := range q.Phrases {
+		words = append(words, phrase...)
+	}
+	if len(words) > 0 {
+		candidates = idx.intersect(words)
+	} else {
+		candidates = make([]int, 0, hi-lo)
+		for i := lo; i < hi; i++ {
+			candidates = append(candidates, i)
+		}
+	}
+
+	var result []int
+	for _, i := range candidates {
+		if i < lo || i >= hi {
+			continue
+		}
+		msg := idx.messages[i]
+		if q.Reply && !msg.IsReply {
+			continue
+		}
+		if q.Forwarded && !msg.IsForwarded {
+			continue
+		}
+		if q.Author != "" && !matchesAuthor(msg.From, q.Author) {
+			continue
+		}
+		if len(q.Phrases) > 0 && !containsPhrases(tokenize(msg.Text), q.Phrases) {
+			continue
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+// matchesAuthor reports whether a sender is the person named in a query: the
+// whole name, or whole words of it, so "аня" finds "Аня Петрова" but not "Таня"
+func matchesAuthor(from, author string) bool {
+	name := strings.Fields(strings.ToLower(from))
+	words := strings.Fields(author)
+	if len(words) == 0 {
+		return false
+	}
+	for _, w := range words {
+		found := false
+		for _, n := range name {
+			if n == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// dateRange returns the half-open range of positions allowed by the date filters
+func (idx *Index) dateRange(q Query) (int, int) {
+	lo, hi := 0, len(idx.messages)
+	if !q.After.IsZero() {
+		lo = sort.Search(len(idx.messages), func(i int) bool {
+			return !idx.messages[i].Date.Before(q.After)
+		})
+	}
+	if !q.Before.IsZero() {
+		hi = sort.Search(len(idx.messages), func(i int) bool {
+			return !idx.messages[i].Date.Before(q.Before)
+		})
+	}
+	return lo, hi
+}
+
+// intersect returns positions of messages containing all the words
+func (idx *Index) intersect(words []string) []int {
+	lists := make([][]int, 0, len(words))
+	for _, word := range words {
+		list, ok := idx.postings[word]
+		if !ok {
+			return nil
+		}
+		lists = append(lists, list)
+	}
+	// Start with the rarest word to keep intermediate results small
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+
+	result := lists[0]
+	for _, list := range lists[1:] {
+		merged := make([]int, 0, len(result))
+		i, j := 0, 0
+		for i < len(result) && j < len(list) {
+			switch {
+			case result[i] == list[j]:
+				merged = append(merged, result[i])
+				i++
+				j++
+			case result[i] < list[j]:
+				i++
+			default:
+				j++
+			}
+		}
+		result = merged
+	}
+	return result
+}
+
+// containsPhrases reports whether every phrase occurs as consecutive words
+func containsPhrases(words []string, phrases [][]string) bool {
+	for _, phrase := range phrases {
+		found := false
+		for start := 0; start+len(phrase) <= len(words) && !found; start++ {
+			found = true
+			for k, w := range phrase {
+				if words[start+k] != w {
+					found = false
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenize splits text into lowercase words; "ё" is treated as "е"
+func tokenize(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query is a parsed search request. All conditions must hold for a message to match.
+type Query struct {
+	Words      []string   // words that must appear in the message
+	Phrases    [][]string // word sequences that must appear in order
+	Author     string     // sender name or some of its words, lowercase
+	After      time.Time  // messages sent on or after this day
+	Before     time.Time  // messages sent before this day
+	Reply      bool       // only replies
+	Forwarded  bool       // only forwarded messages
+	hasFilters bool
+}
+
+// ParseQuery parses a query like `кино "в субботу" author:Аня after:2023-01-01 is:reply`.
+// Dates use the YYYY-MM-DD or DD.MM.YYYY format; before: is exclusive.
+func ParseQuery(text string) (Query, error) {
+	var q Query
+	for _, field := range splitQuery(text) {
+		key, value, ok := strings.Cut(field.text, ":")
+		if field.quoted || !ok || value == "" {
+			q.addText(field.text, field.quoted)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "author", "from":
+			q.Author = strings.ToLower(strings.Trim(value, `"`))
+		case "after", "since":
+			date, err := parseQueryDate(value)
+			if err != nil {
+				return Query{}, err
+			}
+			q.After = date
+		case "before", "until":
+			date, err := parseQueryDate(value)
+			if err != nil {
+				return Query{}, err
+			}
+			q.Before = date
+		case "is":
+			switch strings.ToLower(value) {
+			case "reply":
+				q.Reply = true
+			case "forwarded", "forward":
+				q.Forwarded = true
+			default:
+				return Query{}, fmt.Errorf("unknown filter is:%s", value)
+			}
+		default:
+			// Not a filter, e.g. a time like "12:30"
+			q.addText(field.text, false)
+			continue
+		}
+		q.hasFilters = true
+	}
+
+	if q.IsEmpty() {
+		return Query{}, fmt.Errorf("empty query")
+	}
+	return q, nil
+}
+
+// IsEmpty reports whether the query has neither words nor filters
+func (q Query) IsEmpty() bool {
+	return len(q.Words) == 0 && len(q.Phrases) == 0 && !q.hasFilters
+}
+
+// addText adds a plain word or a quoted phrase to the query
+func (q *Query) addText(text string, quoted bool) {
+	words := tokenize(text)
+	switch {
+	case len(words) == 0:
+	case len(words) == 1 || !quoted:
+		q.Words = append(q.Words, words...)
+	default:
+		q.Phrases = append(q.Phrases, words)
+	}
+}
+
+// queryField is a whitespace-separated part of a query
+type queryField struct {
+	text   string
+	quoted bool
+}
+
+// splitQuery splits a query by spaces, keeping quoted parts together.
+// Quotes after a colon belong to the filter value (author:"Иван Петров").
+func splitQuery(text string) []queryField {
+	var fields []queryField
+	var current strings.Builder
+	inQuotes, quoted := false, false
+
+	flush := func() {
+		if current.Len() > 0 {
+			fields = append(fields, queryField{text: current.String(), quoted: quoted})
+		}
+		current.Reset()
+		quoted = false
+	}
+
+	for _, r := range text {
+		switch {
+		case r == '"' || r == '«' || r == '»':
+			if inQuotes {
+				inQuotes = false
+				if !strings.Contains(current.String(), ":") {
+					flush()
+				}
+				continue
+			}
+			inQuotes = true
+			if current.Len() == 0 {
+				quoted = true
+			}
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return fields
+}
+
+// parseQueryDate parses a date filter value
+func parseQueryDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "02.01.2006", "2006-01"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+}
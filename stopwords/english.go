@@ -0,0 +1,46 @@
+package stopwords
+
+// English contains common English stop words to filter out from analysis
+var English = map[string]bool{
+	// Articles and determiners
+	"the": true, "an": true, "this": true, "that": true, "these": true, "those": true,
+	"some": true, "any": true, "each": true, "every": true, "all": true, "both": true,
+	"no": true, "such": true, "other": true, "another": true,
+
+	// Prepositions
+	"of": true, "in": true, "on": true, "at": true, "to": true, "for": true,
+	"with": true, "from": true, "by": true, "about": true, "into": true, "over": true,
+	"after": true, "before": true, "under": true, "between": true, "through": true,
+	"during": true, "without": true, "up": true, "down": true, "out": true, "off": true,
+
+	// Conjunctions
+	"and": true, "or": true, "but": true, "if": true, "because": true, "so": true,
+	"than": true, "then": true, "as": true, "while": true, "when": true, "where": true,
+	"until": true, "though": true, "although": true,
+
+	// Pronouns
+	"me": true, "you": true, "he": true, "she": true, "it": true, "we": true,
+	"they": true, "him": true, "her": true, "us": true, "them": true, "my": true,
+	"your": true, "his": true, "its": true, "our": true, "their": true, "mine": true,
+	"yours": true, "myself": true, "yourself": true, "what": true, "which": true,
+	"who": true, "whom": true, "whose": true, "there": true, "here": true,
+
+	// Auxiliary verbs
+	"is": true, "am": true, "are": true, "was": true, "were": true, "be": true,
+	"been": true, "being": true, "have": true, "has": true, "had": true, "do": true,
+	"does": true, "did": true, "will": true, "would": true, "shall": true,
+	"should": true, "can": true, "could": true, "may": true, "might": true, "must": true,
+
+	// Contraction parts
+	"im": true, "ive": true, "ll": true, "re": true, "ve": true, "dont": true,
+	"doesnt": true, "didnt": true, "isnt": true, "cant": true, "wont": true,
+
+	// Adverbs and particles
+	"not": true, "just": true, "also": true, "very": true, "too": true, "only": true,
+	"now": true, "how": true, "why": true, "again": true, "still": true, "even": true,
+	"more": true, "most": true, "much": true, "many": true, "yes": true,
+
+	// Chat fillers
+	"ok": true, "okay": true, "lol": true, "yeah": true, "yep": true, "oh": true,
+	"haha": true, "hahaha": true, "btw": true, "idk": true, "omg": true,
+}
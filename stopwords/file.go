@@ -0,0 +1,45 @@
+package stopwords
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadFile reads a word list with one entry per line.
+// Empty lines and lines starting with "#" are skipped.
+func LoadFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open word list: %w", err)
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, strings.ToLower(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list %s: %w", path, err)
+	}
+	return words, nil
+}
+
+// LoadFiles reads and merges several word lists
+func LoadFiles(paths []string) ([]string, error) {
+	var words []string
+	for _, path := range paths {
+		list, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, list...)
+	}
+	return words, nil
+}
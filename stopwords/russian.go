@@ -62,8 +62,3 @@ var Russian = map[string]bool{
 	"ахаха": true, "хаха": true, "ахахах": true, "хахаха": true,
 	"чё": true, "че": true, "ща": true, "щас": true,
 }
-
-// IsStopWord checks if a word is a stop word
-func IsStopWord(word string) bool {
-	return Russian[word]
-}
@@ -0,0 +1,59 @@
+package stopwords
+
+import "unicode"
+
+// byLanguage maps ISO 639-1 language codes to their stop word lists
+var byLanguage = map[string]map[string]bool{
+	"ru": Russian,
+	"uk": Ukrainian,
+	"en": English,
+}
+
+// Languages returns codes of languages with a built-in stop word list
+func Languages() []string {
+	return []string{"ru", "uk", "en"}
+}
+
+// IsStopWordIn checks if a word is a stop word in the given language.
+// Latin words are also checked against the English list, since they are
+// often mixed into Cyrillic chats. Cyrillic words are also checked against the
+// Russian and Ukrainian lists, since short messages are easily detected as the
+// wrong one of the two. An unknown language checks every list.
+func IsStopWordIn(lang, word string) bool {
+	list, ok := byLanguage[lang]
+	if !ok {
+		for _, l := range byLanguage {
+			if l[word] {
+				return true
+			}
+		}
+		return false
+	}
+	if list[word] {
+		return true
+	}
+	if isCyrillic(word) {
+		return Russian[word] || Ukrainian[word]
+	}
+	return isLatin(word) && English[word]
+}
+
+// isLatin checks if a word consists of ASCII letters only
+func isLatin(word string) bool {
+	for _, r := range word {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return word != ""
+}
+
+// isCyrillic checks if a word consists of Cyrillic letters only
+func isCyrillic(word string) bool {
+	for _, r := range word {
+		if !unicode.Is(unicode.Cyrillic, r) {
+			return false
+		}
+	}
+	return word != ""
+}
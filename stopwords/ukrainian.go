@@ -0,0 +1,50 @@
+package stopwords
+
+// Ukrainian contains common Ukrainian stop words to filter out from analysis
+var Ukrainian = map[string]bool{
+	// Прийменники
+	"в": true, "у": true, "на": true, "з": true, "із": true, "зі": true, "до": true,
+	"від": true, "для": true, "по": true, "за": true, "про": true, "під": true,
+	"над": true, "при": true, "без": true, "через": true, "між": true, "перед": true,
+	"після": true, "біля": true, "крізь": true, "о": true, "об": true,
+
+	// Сполучники
+	"і": true, "й": true, "та": true, "а": true, "але": true, "або": true, "чи": true,
+	"що": true, "щоб": true, "якщо": true, "коли": true, "як": true, "бо": true,
+	"тому": true, "тоді": true, "хоча": true, "поки": true, "ніж": true, "де": true,
+
+	// Займенники
+	"я": true, "ти": true, "він": true, "вона": true, "воно": true, "ми": true,
+	"ви": true, "вони": true, "мені": true, "тобі": true, "йому": true, "їй": true,
+	"нам": true, "вам": true, "їм": true, "мене": true, "тебе": true, "його": true,
+	"її": true, "нас": true, "вас": true, "їх": true, "себе": true, "собі": true,
+	"цей": true, "ця": true, "це": true, "ці": true, "цього": true, "цієї": true,
+	"той": true, "те": true, "ті": true, "того": true, "тієї": true,
+	"хто": true, "який": true, "яка": true, "яке": true, "які": true,
+	"мій": true, "моя": true, "моє": true, "мої": true,
+	"твій": true, "твоя": true, "твоє": true, "твої": true,
+	"наш": true, "наша": true, "наше": true, "наші": true,
+	"ваш": true, "ваша": true, "ваше": true, "ваші": true,
+	"свій": true, "своя": true, "своє": true, "свої": true,
+	"весь": true, "вся": true, "все": true, "всі": true, "усі": true, "усе": true,
+
+	// Частки
+	"не": true, "ні": true, "б": true, "би": true, "ж": true, "же": true, "ось": true,
+	"от": true, "навіть": true, "лише": true, "тільки": true, "вже": true, "ще": true,
+	"хіба": true, "невже": true,
+
+	// Прислівники
+	"дуже": true, "там": true, "тут": true, "туди": true, "сюди": true, "звідти": true,
+	"звідси": true, "тепер": true, "зараз": true, "потім": true, "чому": true,
+	"навіщо": true, "куди": true, "звідки": true, "скільки": true, "так": true,
+	"також": true, "теж": true,
+
+	// Допоміжні дієслова
+	"бути": true, "був": true, "була": true, "було": true, "були": true,
+	"буду": true, "буде": true, "будуть": true, "будемо": true, "є": true,
+	"немає": true, "нема": true, "можна": true, "треба": true, "потрібно": true,
+
+	// Інші часті слова
+	"ну": true, "ага": true, "ок": true, "окей": true, "добре": true, "просто": true,
+	"взагалі": true, "лол": true, "хаха": true, "ахах": true,
+}